@@ -7,6 +7,14 @@ import (
 )
 
 type QuakeService interface {
-	// StartExtractingData to start extracting data from log line received from channel and create a report to be sent to writer
-	StartExtractingData(ctx context.Context, rawLinesChan <-chan string, writerChan chan<- dto.Report)
+	// StartExtractingData to start extracting data from log line received from channel and create a report to be sent to writer.
+	// It only returns an error in strict mode, on the first malformed line, and stops reading the lines.
+	// It is not used by the application, which uses StartExtractingGames: it is kept as the sequential reference of the extraction,
+	// which the tests compare with the extraction of the games by the workers.
+	StartExtractingData(ctx context.Context, rawLinesChan <-chan dto.LogLine, writerChan chan<- dto.Report) error
+	// StartExtractingGames to extract the game segments received from channel concurrently, and send their reports to writer in game order.
+	// It only returns an error in strict mode, on the first malformed line, and stops reading the segments.
+	StartExtractingGames(ctx context.Context, segmentsChan <-chan dto.GameSegment, writerChan chan<- dto.Report) error
+	// Diagnostics to get the unparseable and suspicious lines of the last extraction
+	Diagnostics() dto.DiagnosticsReport
 }
@@ -7,5 +7,10 @@ import (
 )
 
 type Writer interface {
+	// StartWriting to write all the reports received from the channel as a single JSON object keyed by game name
 	StartWriting(ctx context.Context, data <-chan dto.Report)
+	// Write to write any data as JSON
+	Write(ctx context.Context, data any)
+	// WriteLines to write each item as a JSON line (NDJSON)
+	WriteLines(ctx context.Context, items []any)
 }
@@ -0,0 +1,184 @@
+package dto
+
+import (
+	"slices"
+
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
+// Award represents the report structure for the winners of an award rule.
+type Award struct {
+	Name    string   `json:"name"`    // Name represents the name of the award.
+	Metric  string   `json:"metric"`  // Metric represents the player stat compared by the award.
+	Winners []string `json:"winners"` // Winners represents the names of the players with the best value, sorted, more than one on a tie.
+	Value   float64  `json:"value"`   // Value represents the best value of the metric.
+}
+
+// EvaluateAwards evaluates the award rules on the player stats of the game.
+func (r Report) EvaluateAwards(rules []entity.AwardRule) []Award {
+	return evaluateAwards(rules, r.metricValues)
+}
+
+// EvaluateAwards evaluates the award rules on the player stats of all the games.
+// The counters are summed across the games, the longest metrics keep the best game and the K/D ratio is computed from the total frags and deaths.
+func (q QuakeDataReport) EvaluateAwards(rules []entity.AwardRule) []Award {
+	return evaluateAwards(rules, q.metricValues)
+}
+
+// metricValues returns the mapping of player names to their value of the rule metric across all the games.
+func (q QuakeDataReport) metricValues(rule entity.AwardRule) map[string]float64 {
+	if rule.Metric == entity.AwardMetricKDRatio {
+		frags := q.metricValues(entity.AwardRule{Metric: entity.AwardMetricFrags})
+		deaths := q.metricValues(entity.AwardRule{Metric: entity.AwardMetricDeaths})
+
+		values := make(map[string]float64)
+		for player := range deaths {
+			values[player] = kdRatio(int(frags[player]), int(deaths[player]))
+		}
+
+		return values
+	}
+
+	values := make(map[string]float64)
+	for _, report := range q {
+		for player, value := range report.metricValues(rule) {
+			current, ok := values[player]
+			switch {
+			case !ok:
+				values[player] = value
+			case rule.Metric.IsMax():
+				values[player] = max(current, value)
+			default:
+				values[player] = current + value
+			}
+		}
+	}
+
+	return values
+}
+
+// metricValues returns the mapping of player names to their value of the rule metric in the game.
+func (r Report) metricValues(rule entity.AwardRule) map[string]float64 {
+	values := make(map[string]float64)
+
+	switch rule.Metric {
+	case entity.AwardMetricKills:
+		for player, kills := range r.Kills {
+			values[player] = float64(kills)
+		}
+	case entity.AwardMetricTeamKills:
+		for player, teamKills := range r.TeamKills {
+			values[player] = float64(teamKills)
+		}
+	case entity.AwardMetricItems:
+		if r.Items == nil {
+			break
+		}
+		for player, classes := range r.Items.ByPlayer {
+			for class, count := range classes {
+				if rule.Class == "" || entity.ItemClass(class) == rule.Class {
+					values[player] += float64(count)
+				}
+			}
+		}
+	case entity.AwardMetricCaptures:
+		if r.CTF == nil {
+			break
+		}
+		for player, stats := range r.CTF.Players {
+			values[player] = float64(stats.Captures)
+		}
+	default:
+		for player, stats := range r.PlayerStats {
+			values[player] = stats.metricValue(rule)
+		}
+	}
+
+	return values
+}
+
+// metricValue returns the value of the rule metric for the player stats.
+func (p PlayerStats) metricValue(rule entity.AwardRule) float64 {
+	switch rule.Metric {
+	case entity.AwardMetricFrags:
+		return float64(p.Frags)
+	case entity.AwardMetricDeaths:
+		return float64(p.Deaths)
+	case entity.AwardMetricSuicides:
+		return float64(p.Suicides)
+	case entity.AwardMetricWorldDeaths:
+		return float64(p.WorldDeaths)
+	case entity.AwardMetricNetScore:
+		return float64(p.NetScore)
+	case entity.AwardMetricKDRatio:
+		return p.KDRatio
+	case entity.AwardMetricLongestStreak:
+		return float64(p.LongestStreak)
+	case entity.AwardMetricLongestLife:
+		return float64(p.LongestLife)
+	}
+
+	value := 0
+	switch rule.Metric {
+	case entity.AwardMetricMultiKills:
+		for _, count := range p.MultiKills {
+			value += count
+		}
+	case entity.AwardMetricKillsByMeans:
+		value = sumByMeans(p.KillsByMeans, rule.Means)
+	case entity.AwardMetricDeathsByMeans:
+		value = sumByMeans(p.DeathsByMeans, rule.Means)
+	}
+
+	return float64(value)
+}
+
+// sumByMeans returns the sum of the counts of the means, which are keyed by weapon on the reports grouped by weapon.
+// Each key is only counted once, so the means of the same weapon are not summed twice on the grouped reports.
+func sumByMeans(counts map[string]int, means []string) int {
+	keys := make(map[string]bool)
+	for _, mean := range means {
+		keys[mean] = true
+		keys[entity.WeaponOf(mean)] = true
+	}
+
+	value := 0
+	for key := range keys {
+		value += counts[key]
+	}
+
+	return value
+}
+
+// evaluateAwards returns the winners of each rule, skipping the rules without winners.
+// The highest value must be greater than zero to win, so nobody gets the rocket award of a game without rockets.
+func evaluateAwards(rules []entity.AwardRule, metricValues func(rule entity.AwardRule) map[string]float64) []Award {
+	var awards []Award
+
+	for _, rule := range rules {
+		award := Award{Name: rule.Name, Metric: string(rule.Metric)}
+
+		for player, value := range metricValues(rule) {
+			isBetter := value > award.Value
+			if rule.Lowest {
+				isBetter = value < award.Value
+			}
+
+			switch {
+			case len(award.Winners) == 0 || isBetter:
+				award.Winners, award.Value = []string{player}, value
+			case value == award.Value:
+				award.Winners = append(award.Winners, player)
+			}
+		}
+
+		if len(award.Winners) == 0 || (!rule.Lowest && award.Value <= 0) {
+			continue
+		}
+
+		slices.Sort(award.Winners)
+		awards = append(awards, award)
+	}
+
+	return awards
+}
@@ -0,0 +1,78 @@
+package dto_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/diegoclair/log-parser/application/dto"
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
+var awardRules = []entity.AwardRule{
+	{Name: "Rocket man", Metric: entity.AwardMetricKillsByMeans, Means: []string{"MOD_ROCKET", "MOD_ROCKET_SPLASH"}},
+	{Name: "Gravity victim", Metric: entity.AwardMetricDeathsByMeans, Means: []string{"MOD_FALLING"}},
+	{Name: "Collector", Metric: entity.AwardMetricItems, Class: entity.ItemClassArmor},
+	{Name: "Survivor", Metric: entity.AwardMetricLongestLife},
+	{Name: "Sharpshooter", Metric: entity.AwardMetricKDRatio},
+	{Name: "Pacifist", Metric: entity.AwardMetricFrags, Lowest: true},
+}
+
+var awardReports = dto.QuakeDataReport{
+	"game_001": {
+		PlayerStats: map[string]dto.PlayerStats{
+			"Player1": {Frags: 3, Deaths: 1, KDRatio: 3, LongestLife: 100, KillsByMeans: map[string]int{"MOD_ROCKET": 2, "MOD_ROCKET_SPLASH": 1}},
+			"Player2": {Frags: 1, Deaths: 3, KDRatio: 0.33, LongestLife: 300, DeathsByMeans: map[string]int{"MOD_FALLING": 2}},
+		},
+		Items: &dto.ItemsReport{
+			ByPlayer: map[string]map[string]int{
+				"Player1": {"armor": 2, "weapon": 5},
+				"Player2": {"armor": 2},
+			},
+		},
+	},
+	"game_002": {
+		PlayerStats: map[string]dto.PlayerStats{
+			"Player1": {Frags: 1, Deaths: 2, KDRatio: 0.5, LongestLife: 50},
+			"Player2": {Frags: 4, Deaths: 1, KDRatio: 4, LongestLife: 200, KillsByMeans: map[string]int{"MOD_ROCKET": 1}},
+		},
+	},
+}
+
+func TestReport_EvaluateAwards(t *testing.T) {
+	want := []dto.Award{
+		{Name: "Rocket man", Metric: "kills_by_means", Winners: []string{"Player1"}, Value: 3},
+		{Name: "Gravity victim", Metric: "deaths_by_means", Winners: []string{"Player2"}, Value: 2},
+		{Name: "Collector", Metric: "items", Winners: []string{"Player1", "Player2"}, Value: 2},
+		{Name: "Survivor", Metric: "longest_life", Winners: []string{"Player2"}, Value: 300},
+		{Name: "Sharpshooter", Metric: "kd_ratio", Winners: []string{"Player1"}, Value: 3},
+		{Name: "Pacifist", Metric: "frags", Winners: []string{"Player2"}, Value: 1},
+	}
+
+	got := awardReports["game_001"].EvaluateAwards(awardRules)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EvaluateAwards() got = %v, want %v", got, want)
+	}
+}
+
+func TestReport_EvaluateAwards_WithoutWinners(t *testing.T) {
+	got := awardReports["game_002"].EvaluateAwards(awardRules[:3])
+	if len(got) != 1 || got[0].Name != "Rocket man" {
+		t.Errorf("EvaluateAwards() got = %v, want only the Rocket man award", got)
+	}
+}
+
+func TestQuakeDataReport_EvaluateAwards(t *testing.T) {
+	want := []dto.Award{
+		{Name: "Rocket man", Metric: "kills_by_means", Winners: []string{"Player1"}, Value: 3},
+		{Name: "Gravity victim", Metric: "deaths_by_means", Winners: []string{"Player2"}, Value: 2},
+		{Name: "Collector", Metric: "items", Winners: []string{"Player1", "Player2"}, Value: 2},
+		{Name: "Survivor", Metric: "longest_life", Winners: []string{"Player2"}, Value: 300},
+		{Name: "Sharpshooter", Metric: "kd_ratio", Winners: []string{"Player1"}, Value: 1.33},
+		{Name: "Pacifist", Metric: "frags", Winners: []string{"Player1"}, Value: 4},
+	}
+
+	got := awardReports.EvaluateAwards(awardRules)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EvaluateAwards() got = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,169 @@
+package dto
+
+import (
+	"math"
+	"sort"
+)
+
+// Career represents the report structure for the totals of a player across all the games.
+type Career struct {
+	Player           string  `json:"player"`            // Player represents the player name.
+	Matches          int     `json:"matches"`           // Matches represents the number of games the player played.
+	Wins             int     `json:"wins"`              // Wins represents the number of complete games won by the player or by the team of the player.
+	Kills            int     `json:"kills"`             // Kills represents the sum of the kills of the player, with the same rules of the game kills.
+	Deaths           int     `json:"deaths"`            // Deaths represents the sum of the deaths of the player, including suicides and <world>.
+	Suicides         int     `json:"suicides"`          // Suicides represents the sum of the suicides of the player.
+	FavouriteWeapon  string  `json:"favourite_weapon"`  // FavouriteWeapon represents the mean the player killed the most with, empty if the player never killed.
+	AveragePlacement float64 `json:"average_placement"` // AveragePlacement represents the average position of the player on the games, 1 being the first.
+}
+
+// Careers returns the careers of all the players of the games, sorted by wins, kills and name, like a season leaderboard.
+func (r QuakeDataReport) Careers() []Career {
+	careers := make(map[string]*Career)
+	placements := make(map[string]int)
+	placedMatches := make(map[string]int)
+	killsByMeans := make(map[string]map[string]int)
+
+	for _, report := range r {
+		winners := report.winners()
+		for _, player := range report.Players {
+			career, ok := careers[player]
+			if !ok {
+				career = &Career{Player: player}
+				careers[player] = career
+				killsByMeans[player] = make(map[string]int)
+			}
+
+			stats := report.PlayerStats[player]
+			career.Matches++
+			career.Kills += report.Kills[player]
+			career.Deaths += stats.Deaths
+			career.Suicides += stats.Suicides
+			if report.scored(player) {
+				placements[player] += report.placement(player)
+				placedMatches[player]++
+			}
+
+			if winners[player] {
+				career.Wins++
+			}
+
+			for mean, kills := range stats.KillsByMeans {
+				killsByMeans[player][mean] += kills
+			}
+		}
+	}
+
+	result := make([]Career, 0, len(careers))
+	for player, career := range careers {
+		career.FavouriteWeapon = favouriteMean(killsByMeans[player])
+		if placedMatches[player] > 0 {
+			career.AveragePlacement = math.Round(float64(placements[player])/float64(placedMatches[player])*100) / 100
+		}
+		result = append(result, *career)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Wins != result[j].Wins {
+			return result[i].Wins > result[j].Wins
+		}
+		if result[i].Kills != result[j].Kills {
+			return result[i].Kills > result[j].Kills
+		}
+		return result[i].Player < result[j].Player
+	})
+
+	return result
+}
+
+// scores returns the mapping of player names to their score in the game, from the official scoreboard if the server printed it.
+// The scoreboard entries are matched by the player of their client slot, so the aliases and the renames are applied.
+func (r Report) scores() map[string]int {
+	scores := make(map[string]int)
+	if len(r.OfficialScoreboard) > 0 {
+		for _, entry := range r.OfficialScoreboard {
+			if entry.Player != "" {
+				scores[entry.Player] = entry.Score
+			}
+		}
+		return scores
+	}
+
+	for _, player := range r.Players {
+		scores[player] = r.Kills[player]
+	}
+
+	return scores
+}
+
+// scored returns whether the player has a score in the game, a player who left before the server printed the scoreboard has none.
+func (r Report) scored(player string) bool {
+	_, ok := r.scores()[player]
+	return ok
+}
+
+// placement returns the position of the player in the game by score, players with the same score share the position.
+// It is only meaningful for the players with a score, see scored.
+func (r Report) placement(player string) int {
+	scores := r.scores()
+
+	placement := 1
+	for _, score := range scores {
+		if score > scores[player] {
+			placement++
+		}
+	}
+
+	return placement
+}
+
+// winners returns the players who won the game: the team with the highest score on team games, or the players with the highest score otherwise.
+// Games that were not played until the end have no winners, as well as team games with a draw.
+func (r Report) winners() map[string]bool {
+	winners := make(map[string]bool)
+	if !r.Complete {
+		return winners
+	}
+
+	if len(r.Teams) > 0 {
+		red, blue := r.Teams["red"], r.Teams["blue"]
+		redScore, blueScore := red.Kills, blue.Kills
+		if red.Score != nil && blue.Score != nil {
+			redScore, blueScore = *red.Score, *blue.Score
+		}
+
+		var players []string
+		switch {
+		case redScore > blueScore:
+			players = red.Players
+		case blueScore > redScore:
+			players = blue.Players
+		}
+
+		for _, player := range players {
+			winners[player] = true
+		}
+
+		return winners
+	}
+
+	for _, player := range r.Players {
+		if r.scored(player) && r.placement(player) == 1 {
+			winners[player] = true
+		}
+	}
+
+	return winners
+}
+
+// favouriteMean returns the mean with the most kills, the first by name on a tie.
+func favouriteMean(killsByMeans map[string]int) string {
+	favourite, most := "", 0
+	for mean, kills := range killsByMeans {
+		if kills > most || (kills == most && mean < favourite) {
+			favourite, most = mean, kills
+		}
+	}
+
+	return favourite
+}
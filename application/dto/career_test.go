@@ -0,0 +1,60 @@
+package dto_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/diegoclair/log-parser/application/dto"
+)
+
+func TestQuakeDataReport_Careers(t *testing.T) {
+	redScore, blueScore := 1, 2
+	reports := dto.QuakeDataReport{
+		"game_001": {
+			Complete: true,
+			Players:  []string{"Player1", "Player2", "Player3"},
+			Kills:    map[string]int{"Player1": 5, "Player2": 3, "Player3": -1},
+			PlayerStats: map[string]dto.PlayerStats{
+				"Player1": {Deaths: 2, KillsByMeans: map[string]int{"MOD_RAILGUN": 3, "MOD_ROCKET": 2}},
+				"Player2": {Deaths: 4, Suicides: 1, KillsByMeans: map[string]int{"MOD_ROCKET": 3}},
+				"Player3": {Deaths: 3},
+			},
+		},
+		"game_002": {
+			Complete: true,
+			Players:  []string{"Player1", "Player2", "Player3"},
+			Kills:    map[string]int{"Player1": 2, "Player2": 1, "Player3": 2},
+			Teams: map[string]dto.TeamReport{
+				"red":  {Players: []string{"Player1"}, Kills: 2, Score: &redScore},
+				"blue": {Players: []string{"Player2", "Player3"}, Kills: 3, Score: &blueScore},
+			},
+			PlayerStats: map[string]dto.PlayerStats{
+				"Player1": {Deaths: 1, KillsByMeans: map[string]int{"MOD_ROCKET": 2}},
+			},
+		},
+		"game_003": {
+			Players: []string{"Player1"},
+			Kills:   map[string]int{},
+		},
+		// the scoreboard is matched by the player of the slot, and a player missing from it has no placement in the game
+		"game_004": {
+			Complete: true,
+			Players:  []string{"Player1", "Player2"},
+			Kills:    map[string]int{},
+			OfficialScoreboard: []dto.ScoreboardEntry{
+				{Name: "P2", Player: "Player2", Score: 3},
+			},
+		},
+	}
+
+	want := []dto.Career{
+		{Player: "Player2", Matches: 3, Wins: 2, Kills: 4, Deaths: 4, Suicides: 1, FavouriteWeapon: "MOD_ROCKET", AveragePlacement: 2},
+		{Player: "Player1", Matches: 4, Wins: 1, Kills: 7, Deaths: 3, FavouriteWeapon: "MOD_ROCKET", AveragePlacement: 1},
+		{Player: "Player3", Matches: 2, Wins: 1, Kills: 1, Deaths: 3, AveragePlacement: 2},
+	}
+
+	got := reports.Careers()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Careers() got = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,59 @@
+package dto
+
+// ChatMessage represents the report structure for a chat message of a game.
+type ChatMessage struct {
+	Player   string   `json:"player"`              // Player represents the name of the player who sent the message.
+	Time     string   `json:"time"`                // Time represents the server time of the message, in the mm:ss log format.
+	Message  string   `json:"message"`             // Message represents the text of the message.
+	TeamOnly bool     `json:"team_only,omitempty"` // TeamOnly represents if the message was sent only to the team of the player.
+	Flagged  []string `json:"flagged,omitempty"`   // Flagged represents the words of the word list found in the message.
+}
+
+// FlaggedMessage represents the report structure for a chat message with words of the word list.
+type FlaggedMessage struct {
+	Game    string   `json:"game,omitempty"` // Game represents the game name, only set on the moderation report of all the games.
+	Time    string   `json:"time"`           // Time represents the server time of the message, in the mm:ss log format.
+	Message string   `json:"message"`        // Message represents the text of the message.
+	Words   []string `json:"words"`          // Words represents the words of the word list found in the message.
+}
+
+// ModerationReport represents the mapping of player names to their flagged messages, in the order they were sent.
+type ModerationReport map[string][]FlaggedMessage
+
+// toModerationReport returns the flagged messages of the chat by player, nil if no message was flagged.
+func toModerationReport(chat []ChatMessage) ModerationReport {
+	var moderation ModerationReport
+
+	for _, message := range chat {
+		if len(message.Flagged) == 0 {
+			continue
+		}
+
+		if moderation == nil {
+			moderation = make(ModerationReport)
+		}
+		moderation[message.Player] = append(moderation[message.Player], FlaggedMessage{
+			Time:    message.Time,
+			Message: message.Message,
+			Words:   message.Flagged,
+		})
+	}
+
+	return moderation
+}
+
+// Moderation returns the flagged messages of all the games by player, in log order.
+func (r QuakeDataReport) Moderation() ModerationReport {
+	moderation := make(ModerationReport)
+
+	for _, name := range r.GameNames() {
+		for player, messages := range r[name].Moderation {
+			for _, message := range messages {
+				message.Game = name
+				moderation[player] = append(moderation[player], message)
+			}
+		}
+	}
+
+	return moderation
+}
@@ -0,0 +1,39 @@
+package dto_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/diegoclair/log-parser/application/dto"
+)
+
+func TestQuakeDataReport_Moderation(t *testing.T) {
+	reports := dto.QuakeDataReport{
+		"game_010": {
+			Moderation: dto.ModerationReport{
+				"Player1": {{Time: "1:00", Message: "camper", Words: []string{"camper"}}},
+			},
+		},
+		"game_002": {
+			Moderation: dto.ModerationReport{
+				"Player1": {{Time: "2:00", Message: "noob", Words: []string{"noob"}}},
+				"Player2": {{Time: "3:00", Message: "noob camper", Words: []string{"noob", "camper"}}},
+			},
+		},
+		"game_003": {},
+	}
+
+	want := dto.ModerationReport{
+		"Player1": {
+			{Game: "game_002", Time: "2:00", Message: "noob", Words: []string{"noob"}},
+			{Game: "game_010", Time: "1:00", Message: "camper", Words: []string{"camper"}},
+		},
+		"Player2": {
+			{Game: "game_002", Time: "3:00", Message: "noob camper", Words: []string{"noob", "camper"}},
+		},
+	}
+
+	if got := reports.Moderation(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Moderation() got = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,102 @@
+package dto
+
+import "github.com/diegoclair/log-parser/domain/entity"
+
+// LogLine represents a line of a game log, with its position in the log.
+// The reader splits each line once with entity.Tokenize and keeps the token, so the line is not split again to be parsed.
+type LogLine struct {
+	Text      string       // Text is the line, without the line break.
+	Token     entity.Token // Token is the line split by entity.Tokenize, only set if Tokenized is true.
+	Tokenized bool         // Tokenized is true if the reader split the line into Token, false if it has no timestamp or was not split.
+	Source    string       // Source is the name of the log file, empty if the log is not a file.
+	Number    int          // Number is the line number in the log, starting at 1.
+	Offset    int64        // Offset is the byte offset of the start of the line in the log.
+	Partial   bool         // Partial is true for the start of a record cut by a crash of the server, which had the next record glued to it.
+	Glued     bool         // Glued is true for a record glued after a partial record, on the same line of the log.
+}
+
+// Event returns the typed event of the line, from its token if the reader split it, or from its text otherwise.
+func (l LogLine) Event() (entity.Event, error) {
+	if l.Tokenized {
+		return l.Token.Event()
+	}
+
+	return entity.ParseLine(l.Text)
+}
+
+// IsInitGame reports whether the line starts a new game.
+func (l LogLine) IsInitGame() bool {
+	if !l.Tokenized {
+		l.Token, l.Tokenized = entity.Tokenize(l.Text)
+	}
+
+	return l.Tokenized && l.Token.Kind == entity.KindInitGame && !l.Partial
+}
+
+// DiagnosticSeverity represents how bad a diagnosed line is.
+type DiagnosticSeverity string
+
+const (
+	DiagnosticError   DiagnosticSeverity = "error"   // The line could not be parsed and was skipped.
+	DiagnosticWarning DiagnosticSeverity = "warning" // The line was parsed, or ignored, but it is suspicious.
+)
+
+// DiagnosticReason represents the kind of problem of a diagnosed line.
+type DiagnosticReason string
+
+const (
+	DiagnosticMalformedLine  DiagnosticReason = "malformed_line"  // The line has no timestamp or does not match the format of its kind.
+	DiagnosticUnknownEvent   DiagnosticReason = "unknown_event"   // The kind of the line is not recognised.
+	DiagnosticInvalidMean    DiagnosticReason = "invalid_mean"    // The mean of death of a kill is unknown or does not match its ID.
+	DiagnosticClockBackwards DiagnosticReason = "clock_backwards" // The timestamp is before the one of the previous line of the game.
+	DiagnosticPartialLine    DiagnosticReason = "partial_line"    // The line was cut by a crash of the server and the next record was glued to it.
+)
+
+// Diagnostic represents the report structure for an unparseable or suspicious line of the log.
+type Diagnostic struct {
+	Source   string             `json:"source,omitempty"` // Source represents the name of the log file.
+	Line     int                `json:"line"`             // Line represents the line number in the log.
+	Offset   int64              `json:"offset"`           // Offset represents the byte offset of the start of the line in the log.
+	Game     string             `json:"game,omitempty"`   // Game represents the name of the game of the line, empty before the first game.
+	Severity DiagnosticSeverity `json:"severity"`         // Severity represents how bad the line is.
+	Reason   DiagnosticReason   `json:"reason"`           // Reason represents the kind of problem of the line.
+	Detail   string             `json:"detail"`           // Detail represents the description of the problem.
+	Text     string             `json:"text"`             // Text represents the content of the line.
+}
+
+// DiagnosticsReport represents the report structure for the diagnostics of a run.
+type DiagnosticsReport struct {
+	Lines       int                      `json:"lines"`       // Lines represents the number of lines read, a line with glued records counts once.
+	Errors      int                      `json:"errors"`      // Errors represents the number of lines that could not be parsed.
+	Warnings    int                      `json:"warnings"`    // Warnings represents the number of suspicious lines.
+	ByReason    map[DiagnosticReason]int `json:"by_reason"`   // ByReason represents the mapping of reasons to their number of lines.
+	Diagnostics []Diagnostic             `json:"diagnostics"` // Diagnostics represents the diagnosed lines, in log order.
+}
+
+// NewDiagnosticsReport creates an empty diagnostics report.
+func NewDiagnosticsReport() DiagnosticsReport {
+	return DiagnosticsReport{
+		ByReason:    make(map[DiagnosticReason]int),
+		Diagnostics: make([]Diagnostic, 0),
+	}
+}
+
+// Add adds the diagnostic to the report and updates the counters.
+func (d *DiagnosticsReport) Add(diagnostic Diagnostic) {
+	d.Diagnostics = append(d.Diagnostics, diagnostic)
+	d.ByReason[diagnostic.Reason]++
+
+	if diagnostic.Severity == DiagnosticError {
+		d.Errors++
+		return
+	}
+	d.Warnings++
+}
+
+// Merge adds the lines and the diagnostics of other to the report, after its own diagnostics.
+func (d *DiagnosticsReport) Merge(other DiagnosticsReport) {
+	d.Lines += other.Lines
+	for _, diagnostic := range other.Diagnostics {
+		d.Add(diagnostic)
+	}
+}
@@ -0,0 +1,34 @@
+package dto_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/diegoclair/log-parser/application/dto"
+)
+
+func TestDiagnosticsReport_Merge(t *testing.T) {
+	malformed := dto.Diagnostic{Line: 2, Severity: dto.DiagnosticError, Reason: dto.DiagnosticMalformedLine}
+	unknown := dto.Diagnostic{Line: 5, Game: "game_001", Severity: dto.DiagnosticWarning, Reason: dto.DiagnosticUnknownEvent}
+
+	report := dto.NewDiagnosticsReport()
+	report.Lines = 3
+	report.Add(malformed)
+
+	other := dto.NewDiagnosticsReport()
+	other.Lines = 4
+	other.Add(unknown)
+
+	report.Merge(other)
+
+	want := dto.DiagnosticsReport{
+		Lines:       7,
+		Errors:      1,
+		Warnings:    1,
+		ByReason:    map[dto.DiagnosticReason]int{dto.DiagnosticMalformedLine: 1, dto.DiagnosticUnknownEvent: 1},
+		Diagnostics: []dto.Diagnostic{malformed, unknown},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("Merge() got = %+v, want %+v", report, want)
+	}
+}
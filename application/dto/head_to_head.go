@@ -0,0 +1,76 @@
+package dto
+
+import "sort"
+
+// HeadToHead represents the mapping of killer names to the number of times they killed each victim.
+type HeadToHead map[string]map[string]int
+
+// Add adds count kills of the killer on the victim.
+func (h HeadToHead) Add(killer, victim string, count int) {
+	if h[killer] == nil {
+		h[killer] = make(map[string]int)
+	}
+	h[killer][victim] += count
+}
+
+// Merge adds all the kills of the other matrix into this one.
+func (h HeadToHead) Merge(other HeadToHead) {
+	for killer, victims := range other {
+		for victim, count := range victims {
+			h.Add(killer, victim, count)
+		}
+	}
+}
+
+// Rivalry represents the kills between a player and one of the opponents.
+type Rivalry struct {
+	Opponent string // Opponent represents the opponent name.
+	Kills    int    // Kills represents how many times the player killed the opponent.
+	Deaths   int    // Deaths represents how many times the opponent killed the player.
+}
+
+// Rivals returns the rivalries of the player, sorted by the number of kills between them and by the opponent name.
+func (h HeadToHead) Rivals(player string) []Rivalry {
+	rivalries := make(map[string]Rivalry)
+
+	for victim, count := range h[player] {
+		rivalry := rivalries[victim]
+		rivalry.Opponent = victim
+		rivalry.Kills += count
+		rivalries[victim] = rivalry
+	}
+
+	for killer, victims := range h {
+		if count, ok := victims[player]; ok && killer != player {
+			rivalry := rivalries[killer]
+			rivalry.Opponent = killer
+			rivalry.Deaths += count
+			rivalries[killer] = rivalry
+		}
+	}
+
+	result := make([]Rivalry, 0, len(rivalries))
+	for _, rivalry := range rivalries {
+		result = append(result, rivalry)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		totalI, totalJ := result[i].Kills+result[i].Deaths, result[j].Kills+result[j].Deaths
+		if totalI != totalJ {
+			return totalI > totalJ
+		}
+		return result[i].Opponent < result[j].Opponent
+	})
+
+	return result
+}
+
+// HeadToHead returns the head-to-head matrix of all the games of the report.
+func (r QuakeDataReport) HeadToHead() HeadToHead {
+	headToHead := make(HeadToHead)
+	for _, report := range r {
+		headToHead.Merge(report.HeadToHead)
+	}
+
+	return headToHead
+}
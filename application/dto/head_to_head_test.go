@@ -0,0 +1,73 @@
+package dto_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/diegoclair/log-parser/application/dto"
+)
+
+func TestQuakeDataReport_HeadToHead(t *testing.T) {
+	reports := dto.QuakeDataReport{
+		"game_001": {
+			HeadToHead: dto.HeadToHead{
+				"Player1": {"Player2": 2},
+				"Player2": {"Player1": 1},
+			},
+		},
+		"game_002": {
+			HeadToHead: dto.HeadToHead{
+				"Player1": {"Player2": 1, "Player3": 4},
+			},
+		},
+		"game_003": {},
+	}
+
+	want := dto.HeadToHead{
+		"Player1": {"Player2": 3, "Player3": 4},
+		"Player2": {"Player1": 1},
+	}
+
+	got := reports.HeadToHead()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("HeadToHead() got = %v, want %v", got, want)
+	}
+}
+
+func TestHeadToHead_Rivals(t *testing.T) {
+	headToHead := dto.HeadToHead{
+		"Player1": {"Player2": 3, "Player3": 1},
+		"Player2": {"Player1": 2},
+		"Player4": {"Player1": 1},
+	}
+
+	tests := []struct {
+		name   string
+		player string
+		want   []dto.Rivalry
+	}{
+		{
+			name:   "should return the rivalries sorted by the kills between the players",
+			player: "Player1",
+			want: []dto.Rivalry{
+				{Opponent: "Player2", Kills: 3, Deaths: 2},
+				{Opponent: "Player3", Kills: 1},
+				{Opponent: "Player4", Deaths: 1},
+			},
+		},
+		{
+			name:   "should return an empty list for an unknown player",
+			player: "Unknown",
+			want:   []dto.Rivalry{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := headToHead.Rivals(tt.player)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Rivals() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package dto
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
+// ScoreSnapshot represents the scores of the players after a kill that changed them.
+type ScoreSnapshot struct {
+	Time   entity.GameTime // Time represents the time of the kill.
+	Scores map[int]int     // Scores represents the mapping of player IDs to their kills score after the kill.
+}
+
+// ScoreProgression represents the report structure for the score of the players over the match time.
+type ScoreProgression struct {
+	Interval  int           `json:"interval"`  // Interval represents the seconds between the samples of the intervals series.
+	Events    []ScoreSample `json:"events"`    // Events represents the scores after each kill that changed them, in log order.
+	Intervals []ScoreSample `json:"intervals"` // Intervals represents the scores sampled at every interval since the start, and at the end of the match.
+}
+
+// ScoreSample represents the report structure for the scores of the players at a moment of the match.
+type ScoreSample struct {
+	Time    string         `json:"time"`    // Time represents the server time of the sample, in the mm:ss log format.
+	Elapsed int            `json:"elapsed"` // Elapsed represents the seconds since the start of the match.
+	Scores  map[string]int `json:"scores"`  // Scores represents the mapping of player names to their kills score, every player of the match included.
+	Leaders []string       `json:"leaders"` // Leaders represents the names of the players with the highest score, sorted, more than one on a tie.
+}
+
+// RecordScores appends a snapshot of the kills score if the kill changed the score of the killer or of the killed player.
+func (q *QuakeData) RecordScores(event entity.KillEvent) {
+	if len(q.ScoreHistory) > 0 {
+		last := q.ScoreHistory[len(q.ScoreHistory)-1].Scores
+		if last[event.KillerID] == q.Kills[event.KillerID] && last[event.KilledID] == q.Kills[event.KilledID] {
+			return
+		}
+	}
+
+	q.ScoreHistory = append(q.ScoreHistory, ScoreSnapshot{Time: event.Time, Scores: maps.Clone(q.Kills)})
+}
+
+// ToScoreProgression converts the score snapshots into the score progression report, sampling the intervals series every interval.
+// The scores are the same kills score of the report, so the last sample matches the kills of the players.
+func (q *QuakeData) ToScoreProgression(interval time.Duration) *ScoreProgression {
+	progression := &ScoreProgression{
+		Interval:  int(interval.Seconds()),
+		Events:    make([]ScoreSample, 0, len(q.ScoreHistory)),
+		Intervals: make([]ScoreSample, 0),
+	}
+
+	for _, snapshot := range q.ScoreHistory {
+		progression.Events = append(progression.Events, q.scoreSample(snapshot.Time, snapshot.Scores))
+	}
+
+	duration := q.EndTime.Elapsed(q.StartTime)
+	next, scores := 0, map[int]int{}
+	for elapsed := time.Duration(0); ; elapsed += interval {
+		elapsed = min(elapsed, duration)
+
+		// the snapshots are in log order, so the scores at the sample are the ones of the last snapshot before it
+		for next < len(q.ScoreHistory) && q.ScoreHistory[next].Time.Elapsed(q.StartTime) <= elapsed {
+			scores = q.ScoreHistory[next].Scores
+			next++
+		}
+		progression.Intervals = append(progression.Intervals, q.scoreSample(q.StartTime.Add(elapsed), scores))
+
+		if elapsed == duration {
+			break
+		}
+	}
+
+	return progression
+}
+
+// scoreSample returns the sample of the scores at the time, with every player of the match.
+func (q *QuakeData) scoreSample(at entity.GameTime, scores map[int]int) ScoreSample {
+	sample := ScoreSample{
+		Time:    at.String(),
+		Elapsed: int(at.Elapsed(q.StartTime).Seconds()),
+		Scores:  make(map[string]int),
+		Leaders: make([]string, 0),
+	}
+
+	best := 0
+	for playerID, player := range q.Players {
+		score := scores[playerID]
+		sample.Scores[player] = score
+
+		switch {
+		case len(sample.Leaders) == 0 || score > best:
+			sample.Leaders, best = []string{player}, score
+		case score == best:
+			sample.Leaders = append(sample.Leaders, player)
+		}
+	}
+
+	slices.Sort(sample.Leaders)
+
+	return sample
+}
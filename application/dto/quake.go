@@ -1,34 +1,288 @@
 package dto
 
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/diegoclair/log-parser/application"
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
 // QuakeData represents the data structure for storing quake game information.
 type QuakeData struct {
-	TotalKills   int            // TotalKills represents the total number of kills in the game.
-	Players      map[int]string // Players represents the mapping of player IDs to player names.
-	Kills        map[int]int    // Kills represents the mapping of player IDs to their respective kill counts.
-	KillsByMeans map[string]int // KillsByMeans represents the mapping of kill means to their respective counts.
+	TotalKills    int                              // TotalKills represents the total number of kills in the game.
+	Players       map[int]string                   // Players represents the mapping of player IDs to player names.
+	Slots         map[int]int                      // Slots represents the mapping of the client slots of the log to the player IDs.
+	NameHistory   map[int][]string                 // NameHistory represents the mapping of player IDs to the names they used, in order.
+	Kills         map[int]int                      // Kills represents the mapping of player IDs to their respective kill counts.
+	KillsByMeans  map[string]int                   // KillsByMeans represents the mapping of kill means to their respective counts.
+	Config        entity.MatchConfig               // Config represents the server settings of the game.
+	EndReason     entity.EndReason                 // EndReason represents how the game ended, empty while the game is running.
+	StartTime     entity.GameTime                  // StartTime represents the time of the InitGame line.
+	EndTime       entity.GameTime                  // EndTime represents the time of the last line of the game.
+	Scoreboard    []ScoreLine                      // Scoreboard represents the score lines printed by the server at the end of the game.
+	Discrepancies []ScoreDiscrepancy               // Discrepancies represents the players whose computed net score differs from the scoreboard.
+	Teams         map[int]entity.Team              // Teams represents the mapping of player IDs to their current team.
+	TeamKills     map[int]int                      // TeamKills represents the mapping of player IDs to the number of teammates they killed.
+	TeamScore     *entity.TeamScoreEvent           // TeamScore represents the final red and blue scores printed by the server.
+	CTFPlayers    map[int]CTFStats                 // CTFPlayers represents the mapping of player IDs to their capture the flag stats.
+	CTFTeams      map[entity.Team]CTFStats         // CTFTeams represents the mapping of teams to their capture the flag stats.
+	FlagCarriers  map[entity.Team]int              // FlagCarriers represents the mapping of flag teams to the ID of the player carrying it.
+	CTFFromLog    bool                             // CTFFromLog represents if the server prints CTF lines, so the stats are not inferred from items.
+	ItemsByPlayer map[int]map[entity.ItemClass]int // ItemsByPlayer represents the mapping of player IDs to their item pickups by class.
+	ItemsByName   map[string]int                   // ItemsByName represents the mapping of item names to their pickup counts.
+	Powerups      []entity.ItemEvent               // Powerups represents the powerup pickups, in the order they happened.
+	PlayerStats   map[int]*PlayerData              // PlayerStats represents the mapping of player IDs to their detailed stats.
+	HeadToHead    map[int]map[int]int              // HeadToHead represents the mapping of killer IDs to the number of times they killed each victim ID.
+	FirstBlood    *entity.KillEvent                // FirstBlood represents the first kill of a player by another player in the game.
+	EndedStreaks  []EndedStreak                    // EndedStreaks represents the kill streaks ended by a death, in the order they happened.
+	Sessions      map[int][]Session                // Sessions represents the mapping of player IDs to their sessions, in the order they happened.
+	Chat          []ChatMessage                    // Chat represents the chat messages of the game, in the order they were sent.
+	Timeline      []TimelineEvent                  // Timeline represents the events of the game, in log order, only recorded if the timeline is enabled.
+	ScoreHistory  []ScoreSnapshot                  // ScoreHistory represents the scores after each kill that changed them, only recorded if the score progression is enabled.
 }
 
 func (q *QuakeData) Reset() {
 	q.TotalKills = 0
 	q.Players = make(map[int]string)
+	q.Slots = make(map[int]int)
+	q.NameHistory = make(map[int][]string)
 	q.Kills = make(map[int]int)
 	q.KillsByMeans = make(map[string]int)
+	q.Config = entity.MatchConfig{}
+	q.EndReason = ""
+	q.StartTime = entity.GameTime{}
+	q.EndTime = entity.GameTime{}
+	q.Scoreboard = nil
+	q.Discrepancies = nil
+	q.Teams = make(map[int]entity.Team)
+	q.TeamKills = make(map[int]int)
+	q.TeamScore = nil
+	q.ResetCTF()
+	q.ItemsByPlayer = make(map[int]map[entity.ItemClass]int)
+	q.ItemsByName = make(map[string]int)
+	q.Powerups = nil
+	q.PlayerStats = make(map[int]*PlayerData)
+	q.HeadToHead = make(map[int]map[int]int)
+	q.FirstBlood = nil
+	q.EndedStreaks = nil
+	q.Sessions = make(map[int][]Session)
+	q.Chat = nil
+	q.Timeline = nil
+	q.ScoreHistory = nil
+}
+
+// PlayerData represents the data structure for storing the detailed stats of a player in a game.
+type PlayerData struct {
+	Frags         int             // Frags represents the kills of other players, without team kills.
+	Deaths        int             // Deaths represents all the deaths of the player, including suicides and world kills.
+	Suicides      int             // Suicides represents the times the player killed themself.
+	WorldDeaths   int             // WorldDeaths represents the times the player was killed by <world>.
+	KillsByMeans  map[string]int  // KillsByMeans represents the mapping of kill means to the kills made by the player.
+	DeathsByMeans map[string]int  // DeathsByMeans represents the mapping of kill means to the deaths of the player.
+	Streak        int             // Streak represents the kills of the player since the last death.
+	LongestStreak int             // LongestStreak represents the most kills of the player without dying.
+	MultiKill     int             // MultiKill represents the size of the current multi-kill of the player.
+	LastKillTime  entity.GameTime // LastKillTime represents the time of the last kill of the player.
+	MultiKills    map[int]int     // MultiKills represents the mapping of multi-kill sizes to the times the player made them.
+	LastDeathTime entity.GameTime // LastDeathTime represents the time of the last death of the player.
+	LongestLife   time.Duration   // LongestLife represents the longest time between two deaths of the player.
+}
+
+// Life returns the time the player is alive at the given time, since the last death or the start of the game.
+func (p *PlayerData) Life(at, gameStart entity.GameTime) time.Duration {
+	if p.Deaths == 0 {
+		return at.Elapsed(gameStart)
+	}
+
+	return at.Elapsed(p.LastDeathTime)
+}
+
+// EndedStreak represents a kill streak ended by the death of the player.
+type EndedStreak struct {
+	PlayerID  int             // PlayerID represents the ID of the player who had the streak.
+	Streak    int             // Streak represents the number of kills of the streak.
+	EndedByID int             // EndedByID represents the ID of the killer, the player itself on a suicide or the world.
+	Time      entity.GameTime // Time represents the time of the death.
+}
+
+// PlayerID returns the ID of the player bound to the client slot, or the slot itself if it has no player, like the world.
+func (q *QuakeData) PlayerID(slot int) int {
+	if playerID, ok := q.Slots[slot]; ok {
+		return playerID
+	}
+
+	return slot
+}
+
+// NetScore returns the frags of the player minus the suicides, the <world> deaths and the team kills, like the score of the server.
+func (q *QuakeData) NetScore(playerID int) int {
+	netScore := -q.TeamKills[playerID]
+	if stats, ok := q.PlayerStats[playerID]; ok {
+		netScore += stats.Frags - stats.Suicides - stats.WorldDeaths
+	}
+
+	return netScore
+}
+
+// Session represents the time a player was in the game, from the begin line to the disconnect line or the end of the game.
+type Session struct {
+	Join      entity.GameTime // Join represents the time of the begin line.
+	Leave     entity.GameTime // Leave represents the time of the disconnect line, if the session is closed.
+	Open      bool            // Open represents if the player is still in the game.
+	LeftEarly bool            // LeftEarly represents if the player disconnected before the exit or the shutdown of the game.
+}
+
+// GetPlayerStats returns the detailed stats of the player, creating them if the player has no stats yet.
+func (q *QuakeData) GetPlayerStats(playerID int) *PlayerData {
+	stats, ok := q.PlayerStats[playerID]
+	if !ok {
+		stats = &PlayerData{
+			KillsByMeans:  make(map[string]int),
+			DeathsByMeans: make(map[string]int),
+		}
+		q.PlayerStats[playerID] = stats
+	}
+
+	return stats
+}
+
+// ResetCTF resets the capture the flag stats of the game.
+func (q *QuakeData) ResetCTF() {
+	q.CTFPlayers = make(map[int]CTFStats)
+	q.CTFTeams = make(map[entity.Team]CTFStats)
+	q.FlagCarriers = make(map[entity.Team]int)
 }
 
 // ToReport converts the QuakeData into a Report object.
 func (q *QuakeData) ToReport(gameName string) Report {
 	report := Report{
-		GameName:     gameName,
-		TotalKills:   q.TotalKills,
-		Players:      make([]string, 0),
-		Kills:        make(map[string]int),
-		KillsByMeans: q.KillsByMeans,
+		GameName:      gameName,
+		TotalKills:    q.TotalKills,
+		Players:       make([]string, 0),
+		Kills:         make(map[string]int),
+		KillsByMeans:  q.KillsByMeans,
+		MatchConfig:   newMatchConfig(q.Config),
+		EndReason:     string(q.EndReason),
+		Complete:      q.EndReason.IsComplete(),
+		StartTime:     q.StartTime.String(),
+		EndTime:       q.EndTime.String(),
+		Duration:      int(q.EndTime.Elapsed(q.StartTime).Seconds()),
+		Discrepancies: q.Discrepancies,
+		Fingerprint:   q.Fingerprint(),
+	}
+
+	if q.Config.GameType.IsTeamGame() {
+		report.Teams = q.toTeamsReport()
+	}
+
+	for playerID, teamKills := range q.TeamKills {
+		if report.TeamKills == nil {
+			report.TeamKills = make(map[string]int)
+		}
+		report.TeamKills[q.Players[playerID]] = teamKills
+	}
+
+	if q.Config.GameType == entity.GameTypeCTF || len(q.CTFPlayers) > 0 {
+		report.CTF = q.toCTFReport()
+	}
+
+	if len(q.ItemsByName) > 0 {
+		report.Items = q.toItemsReport()
+	}
+
+	for playerID, stats := range q.PlayerStats {
+		// stats of slots without a known player, like a suicide before the user info, are not reported
+		if _, ok := q.Players[playerID]; !ok {
+			continue
+		}
+
+		if report.PlayerStats == nil {
+			report.PlayerStats = make(map[string]PlayerStats)
+		}
+		report.PlayerStats[q.Players[playerID]] = PlayerStats{
+			Frags:         stats.Frags,
+			Deaths:        stats.Deaths,
+			Suicides:      stats.Suicides,
+			WorldDeaths:   stats.WorldDeaths,
+			NetScore:      q.NetScore(playerID),
+			KDRatio:       kdRatio(stats.Frags, stats.Deaths),
+			LongestStreak: stats.LongestStreak,
+			LongestLife:   int(max(stats.LongestLife, stats.Life(q.EndTime, q.StartTime)).Seconds()),
+			MultiKills:    stats.MultiKills,
+			KillsByMeans:  stats.KillsByMeans,
+			DeathsByMeans: stats.DeathsByMeans,
+		}
+	}
+
+	if len(q.Sessions) > 0 {
+		report.Sessions = q.toSessionsReport()
+	}
+
+	report.Chat = q.Chat
+	report.Timeline = q.Timeline
+	report.Moderation = toModerationReport(q.Chat)
+
+	if q.FirstBlood != nil {
+		report.FirstBlood = &FirstBlood{
+			Killer:  q.Players[q.FirstBlood.KillerID],
+			Victim:  q.Players[q.FirstBlood.KilledID],
+			Time:    q.FirstBlood.Time.String(),
+			Elapsed: int(q.FirstBlood.Time.Elapsed(q.StartTime).Seconds()),
+		}
+	}
+
+	for _, ended := range q.EndedStreaks {
+		report.EndedStreaks = append(report.EndedStreaks, StreakEnd{
+			Player:  q.Players[ended.PlayerID],
+			Streak:  ended.Streak,
+			EndedBy: q.PlayerName(ended.EndedByID),
+			Time:    ended.Time.String(),
+		})
+	}
+
+	for killerID, victims := range q.HeadToHead {
+		if report.HeadToHead == nil {
+			report.HeadToHead = make(HeadToHead)
+		}
+		for victimID, count := range victims {
+			killer, okKiller := q.Players[killerID]
+			victim, okVictim := q.Players[victimID]
+			if !okKiller || !okVictim {
+				continue
+			}
+
+			report.HeadToHead.Add(killer, victim, count)
+		}
+	}
+
+	for _, score := range q.Scoreboard {
+		report.OfficialScoreboard = append(report.OfficialScoreboard, ScoreboardEntry{
+			Name:     score.Name,
+			Player:   q.Players[score.PlayerID],
+			Score:    score.Score,
+			Ping:     score.Ping,
+			ClientID: score.ClientID,
+		})
 	}
 
 	for _, player := range q.Players {
 		report.Players = append(report.Players, player)
 	}
 
+	for playerID, names := range q.NameHistory {
+		// the history is only reported for the players who changed their name or used an alias
+		if len(names) == 1 && names[0] == q.Players[playerID] {
+			continue
+		}
+
+		if report.NameHistory == nil {
+			report.NameHistory = make(map[string][]string)
+		}
+		report.NameHistory[q.Players[playerID]] = names
+	}
+
 	for playerID, playerKills := range q.Kills {
 		report.Kills[q.Players[playerID]] = playerKills
 	}
@@ -36,14 +290,359 @@ func (q *QuakeData) ToReport(gameName string) Report {
 	return report
 }
 
+// PlayerName returns the name of the player, or <world> for the world kills.
+func (q *QuakeData) PlayerName(playerID int) string {
+	if playerID == application.WorldPlayerID {
+		return "<world>"
+	}
+
+	return q.Players[playerID]
+}
+
+// toTeamsReport converts the team data of a team game into the red and blue team reports.
+func (q *QuakeData) toTeamsReport() map[string]TeamReport {
+	teams := map[string]TeamReport{
+		entity.TeamRed.String():  {Players: make([]string, 0)},
+		entity.TeamBlue.String(): {Players: make([]string, 0)},
+	}
+
+	for playerID, team := range q.Teams {
+		if !team.IsPlaying() {
+			continue
+		}
+
+		teamReport := teams[team.String()]
+		teamReport.Players = append(teamReport.Players, q.Players[playerID])
+		teamReport.Kills += q.Kills[playerID]
+		teamReport.TeamKills += q.TeamKills[playerID]
+		teams[team.String()] = teamReport
+	}
+
+	for _, teamReport := range teams {
+		slices.Sort(teamReport.Players)
+	}
+
+	if q.TeamScore != nil {
+		red, blue := teams[entity.TeamRed.String()], teams[entity.TeamBlue.String()]
+		red.Score, blue.Score = &q.TeamScore.Red, &q.TeamScore.Blue
+		teams[entity.TeamRed.String()], teams[entity.TeamBlue.String()] = red, blue
+	}
+
+	return teams
+}
+
+// toCTFReport converts the capture the flag stats into the ctf report.
+func (q *QuakeData) toCTFReport() *CTFReport {
+	ctf := &CTFReport{
+		Players: make(map[string]CTFStats),
+		Teams: map[string]CTFStats{
+			entity.TeamRed.String():  q.CTFTeams[entity.TeamRed],
+			entity.TeamBlue.String(): q.CTFTeams[entity.TeamBlue],
+		},
+	}
+
+	for playerID, stats := range q.CTFPlayers {
+		ctf.Players[q.Players[playerID]] = stats
+	}
+
+	return ctf
+}
+
+// toSessionsReport converts the sessions of the players into the sessions report, with the per-minute stats over the time played.
+// The open sessions are closed at the end of the game.
+func (q *QuakeData) toSessionsReport() map[string]PlayerSessions {
+	players := make(map[string]PlayerSessions)
+
+	for playerID, sessions := range q.Sessions {
+		name, ok := q.Players[playerID]
+		if !ok {
+			continue
+		}
+
+		report := PlayerSessions{Sessions: make([]SessionReport, 0, len(sessions))}
+		for _, session := range sessions {
+			leave := session.Leave
+			if session.Open {
+				leave = q.EndTime
+			}
+
+			duration := int(leave.Elapsed(session.Join).Seconds())
+			report.TimePlayed += duration
+			report.LeftEarly = session.LeftEarly
+			report.Sessions = append(report.Sessions, SessionReport{
+				Join:      session.Join.String(),
+				Leave:     leave.String(),
+				Duration:  duration,
+				LeftEarly: session.LeftEarly,
+			})
+		}
+
+		if stats, ok := q.PlayerStats[playerID]; ok && report.TimePlayed > 0 {
+			minutes := float64(report.TimePlayed) / 60
+			report.KillsPerMinute = math.Round(float64(stats.Frags)/minutes*100) / 100
+			report.DeathsPerMinute = math.Round(float64(stats.Deaths)/minutes*100) / 100
+		}
+
+		players[name] = report
+	}
+
+	return players
+}
+
+// kdRatio returns the frags by deaths ratio rounded to two decimals, or the frags if the player never died.
+func kdRatio(frags, deaths int) float64 {
+	if deaths == 0 {
+		return float64(frags)
+	}
+
+	return math.Round(float64(frags)/float64(deaths)*100) / 100
+}
+
+// toItemsReport converts the item pickups into the items report.
+func (q *QuakeData) toItemsReport() *ItemsReport {
+	items := &ItemsReport{
+		ByPlayer: make(map[string]map[string]int),
+		ByClass:  make(map[string]int),
+		ByItem:   q.ItemsByName,
+		Powerups: make([]PowerupPickup, 0, len(q.Powerups)),
+	}
+
+	for playerID, classes := range q.ItemsByPlayer {
+		byClass := make(map[string]int)
+		for class, count := range classes {
+			byClass[string(class)] += count
+			items.ByClass[string(class)] += count
+		}
+		items.ByPlayer[q.Players[playerID]] = byClass
+	}
+
+	for _, powerup := range q.Powerups {
+		items.Powerups = append(items.Powerups, PowerupPickup{
+			Player:  q.Players[powerup.ClientID],
+			Item:    powerup.Item,
+			Time:    powerup.Time.String(),
+			Elapsed: int(powerup.Time.Elapsed(q.StartTime).Seconds()),
+		})
+	}
+
+	return items
+}
+
 // Report represents the report structure for a Quake game.
 type Report struct {
-	GameName     string         `json:"-"`              // GameName represents the name of the game.
-	TotalKills   int            `json:"total_kills"`    // TotalKills represents the total number of kills in the game.
-	Players      []string       `json:"players"`        // Players represents the list of player names.
-	Kills        map[string]int `json:"kills"`          // Kills represents the mapping of player names to their respective kill counts.
-	KillsByMeans map[string]int `json:"kills_by_means"` // KillsByMeans represents the mapping of kill means to their respective counts.
+	GameName           string                    `json:"-"`                             // GameName represents the name of the game.
+	TotalKills         int                       `json:"total_kills"`                   // TotalKills represents the total number of kills in the game.
+	Players            []string                  `json:"players"`                       // Players represents the list of player names.
+	Kills              map[string]int            `json:"kills"`                         // Kills represents the mapping of player names to their respective kill counts.
+	KillsByMeans       map[string]int            `json:"kills_by_means"`                // KillsByMeans represents the mapping of kill means to their respective counts.
+	MatchConfig        MatchConfig               `json:"match_config"`                  // MatchConfig represents the server settings of the game.
+	EndReason          string                    `json:"end_reason"`                    // EndReason represents how the game ended: fraglimit, timelimit, capturelimit, exit, shutdown or truncated.
+	Complete           bool                      `json:"complete"`                      // Complete represents if the game was played until the server exited it.
+	StartTime          string                    `json:"start_time"`                    // StartTime represents the server time when the game started, in the mm:ss log format.
+	EndTime            string                    `json:"end_time"`                      // EndTime represents the server time of the last line of the game, in the mm:ss log format.
+	Duration           int                       `json:"duration"`                      // Duration represents the duration of the game, in seconds.
+	OfficialScoreboard []ScoreboardEntry         `json:"official_scoreboard,omitempty"` // OfficialScoreboard represents the final scoreboard printed by the server, in the printed order.
+	Discrepancies      []ScoreDiscrepancy        `json:"score_discrepancies,omitempty"` // Discrepancies represents the players whose computed kills differ from the official scoreboard.
+	Teams              map[string]TeamReport     `json:"teams,omitempty"`               // Teams represents the red and blue teams of a team game.
+	TeamKills          map[string]int            `json:"team_kills,omitempty"`          // TeamKills represents the mapping of player names to the number of teammates they killed.
+	CTF                *CTFReport                `json:"ctf,omitempty"`                 // CTF represents the capture the flag stats of the game.
+	Items              *ItemsReport              `json:"items,omitempty"`               // Items represents the item pickups of the game.
+	PlayerStats        map[string]PlayerStats    `json:"player_stats,omitempty"`        // PlayerStats represents the mapping of player names to their detailed stats.
+	HeadToHead         HeadToHead                `json:"head_to_head,omitempty"`        // HeadToHead represents the mapping of killer names to the number of times they killed each victim.
+	FirstBlood         *FirstBlood               `json:"first_blood,omitempty"`         // FirstBlood represents the first kill of a player by another player in the game.
+	EndedStreaks       []StreakEnd               `json:"ended_streaks,omitempty"`       // EndedStreaks represents the kill streaks ended by a death, in the order they happened.
+	Awards             []Award                   `json:"awards,omitempty"`              // Awards represents the winners of the configured award rules.
+	NameHistory        map[string][]string       `json:"name_history,omitempty"`        // NameHistory represents the mapping of player names to the names they used, in order, if they changed.
+	Sessions           map[string]PlayerSessions `json:"sessions,omitempty"`            // Sessions represents the mapping of player names to their sessions in the game.
+	Chat               []ChatMessage             `json:"chat,omitempty"`                // Chat represents the chat messages of the game, only reported if the chat is enabled.
+	Moderation         ModerationReport          `json:"moderation,omitempty"`          // Moderation represents the mapping of player names to their messages flagged by the word list.
+	Timeline           []TimelineEvent           `json:"-"`                             // Timeline represents the events of the game, written apart from the report.
+	ScoreProgression   *ScoreProgression         `json:"score_progression,omitempty"`   // ScoreProgression represents the score of the players over the match time, only reported if it is enabled.
+	Fingerprint        string                    `json:"-"`                             // Fingerprint represents the hash that identifies the game across runs, see QuakeData.Fingerprint.
+}
+
+// PlayerSessions represents the report structure for the sessions of a player in a game.
+type PlayerSessions struct {
+	Sessions        []SessionReport `json:"sessions"`          // Sessions represents the sessions of the player, in the order they happened.
+	TimePlayed      int             `json:"time_played"`       // TimePlayed represents the sum of the duration of the sessions, in seconds.
+	LeftEarly       bool            `json:"left_early"`        // LeftEarly represents if the last session of the player ended before the end of the game.
+	KillsPerMinute  float64         `json:"kills_per_minute"`  // KillsPerMinute represents the frags of the player by minute played.
+	DeathsPerMinute float64         `json:"deaths_per_minute"` // DeathsPerMinute represents the deaths of the player by minute played.
+}
+
+// SessionReport represents the report structure for a session of a player.
+type SessionReport struct {
+	Join      string `json:"join"`       // Join represents the server time when the player entered the game, in the mm:ss log format.
+	Leave     string `json:"leave"`      // Leave represents the server time when the player left the game, or the end of the game.
+	Duration  int    `json:"duration"`   // Duration represents the duration of the session, in seconds.
+	LeftEarly bool   `json:"left_early"` // LeftEarly represents if the player disconnected before the end of the game.
+}
+
+// TeamReport represents the report structure for a team of a team game.
+type TeamReport struct {
+	Players   []string `json:"players"`         // Players represents the names of the players on the team at the end of the game.
+	Kills     int      `json:"kills"`           // Kills represents the sum of the kills of the team players.
+	TeamKills int      `json:"team_kills"`      // TeamKills represents the number of teammates killed by the team players.
+	Score     *int     `json:"score,omitempty"` // Score represents the final team score printed by the server, if the game was exited.
+}
+
+// CTFReport represents the report structure for the capture the flag stats of a game.
+type CTFReport struct {
+	Players map[string]CTFStats `json:"players"` // Players represents the mapping of player names to their stats.
+	Teams   map[string]CTFStats `json:"teams"`   // Teams represents the mapping of team names to their stats.
+}
+
+// CTFStats represents the capture the flag counters of a player or a team.
+type CTFStats struct {
+	Pickups  int `json:"pickups"`  // Pickups represents the number of enemy flags picked up.
+	Captures int `json:"captures"` // Captures represents the number of enemy flags captured.
+	Returns  int `json:"returns"`  // Returns represents the number of own flags returned.
+	Defenses int `json:"defenses"` // Defenses represents the number of enemy flag carriers killed.
+}
+
+// Add increments the counter of the given action.
+func (c CTFStats) Add(action entity.CTFAction) CTFStats {
+	switch action {
+	case entity.CTFActionPickup:
+		c.Pickups++
+	case entity.CTFActionCapture:
+		c.Captures++
+	case entity.CTFActionReturn:
+		c.Returns++
+	case entity.CTFActionDefend:
+		c.Defenses++
+	}
+
+	return c
+}
+
+// PlayerStats represents the report structure for the detailed stats of a player.
+type PlayerStats struct {
+	Frags         int            `json:"frags"`                 // Frags represents the kills of other players, without team kills.
+	Deaths        int            `json:"deaths"`                // Deaths represents all the deaths of the player, including suicides and world kills.
+	Suicides      int            `json:"suicides"`              // Suicides represents the times the player killed themself.
+	WorldDeaths   int            `json:"world_deaths"`          // WorldDeaths represents the times the player was killed by <world>.
+	NetScore      int            `json:"net_score"`             // NetScore represents the frags minus suicides, world deaths and team kills, like the server score.
+	KDRatio       float64        `json:"kd_ratio"`              // KDRatio represents the frags by deaths ratio, or the frags if the player never died.
+	KillsByMeans  map[string]int `json:"kills_by_means"`        // KillsByMeans represents the mapping of kill means to the kills made by the player.
+	DeathsByMeans map[string]int `json:"deaths_by_means"`       // DeathsByMeans represents the mapping of kill means to the deaths of the player.
+	LongestStreak int            `json:"longest_streak"`        // LongestStreak represents the most kills of the player without dying.
+	LongestLife   int            `json:"longest_life"`          // LongestLife represents the longest time the player stayed alive, in seconds, including the end of the game.
+	MultiKills    map[int]int    `json:"multi_kills,omitempty"` // MultiKills represents the mapping of multi-kill sizes to the times the player made them.
+}
+
+// FirstBlood represents the report structure for the first kill of a game.
+type FirstBlood struct {
+	Killer  string `json:"killer"`  // Killer represents the name of the player who made the first kill.
+	Victim  string `json:"victim"`  // Victim represents the name of the killed player.
+	Time    string `json:"time"`    // Time represents the server time of the kill, in the mm:ss log format.
+	Elapsed int    `json:"elapsed"` // Elapsed represents the seconds since the start of the game.
+}
+
+// StreakEnd represents the report structure for a kill streak ended by a death.
+type StreakEnd struct {
+	Player  string `json:"player"`   // Player represents the name of the player who had the streak.
+	Streak  int    `json:"streak"`   // Streak represents the number of kills of the streak.
+	EndedBy string `json:"ended_by"` // EndedBy represents the name of the killer, the player itself on a suicide or <world>.
+	Time    string `json:"time"`     // Time represents the server time of the death, in the mm:ss log format.
+}
+
+// ItemsReport represents the report structure for the item pickups of a game.
+type ItemsReport struct {
+	ByPlayer map[string]map[string]int `json:"by_player"` // ByPlayer represents the mapping of player names to their pickups by item class.
+	ByClass  map[string]int            `json:"by_class"`  // ByClass represents the mapping of item classes to their pickup counts.
+	ByItem   map[string]int            `json:"by_item"`   // ByItem represents the mapping of item names to their pickup counts.
+	Powerups []PowerupPickup           `json:"powerups"`  // Powerups represents the powerup pickups, in the order they happened.
+}
+
+// PowerupPickup represents the report structure for a powerup pickup.
+type PowerupPickup struct {
+	Player  string `json:"player"`  // Player represents the name of the player who picked up the powerup.
+	Item    string `json:"item"`    // Item represents the powerup name, like "item_quad".
+	Time    string `json:"time"`    // Time represents the server time of the pickup, in the mm:ss log format.
+	Elapsed int    `json:"elapsed"` // Elapsed represents the seconds since the start of the game.
+}
+
+// ScoreLine represents a score line of the official scoreboard and the player bound to its client slot when the server printed it.
+type ScoreLine struct {
+	entity.ScoreEvent
+	PlayerID int // PlayerID represents the stable ID of the player of the client slot.
+}
+
+// ScoreboardEntry represents the report structure for a line of the official scoreboard.
+type ScoreboardEntry struct {
+	Name     string `json:"name"`      // Name represents the player name printed by the server.
+	Player   string `json:"player"`    // Player represents the name of the player of the client slot in the report, empty if the slot had no player.
+	Score    int    `json:"score"`     // Score represents the frag count given by the server.
+	Ping     int    `json:"ping"`      // Ping represents the player ping.
+	ClientID int    `json:"client_id"` // ClientID represents the client slot of the player.
+}
+
+// ScoreDiscrepancy represents a player whose computed kills differ from the official scoreboard.
+type ScoreDiscrepancy struct {
+	Player   string `json:"player"`   // Player represents the player name.
+	Computed int    `json:"computed"` // Computed represents the net score computed from the kill lines.
+	Official int    `json:"official"` // Official represents the score given by the server.
+}
+
+// MatchConfig represents the report structure for the server settings of a Quake game.
+type MatchConfig struct {
+	MapName      string `json:"map_name"`     // MapName represents the name of the map.
+	GameType     string `json:"game_type"`    // GameType represents the name of the game type, like FFA or CTF.
+	FragLimit    int    `json:"fraglimit"`    // FragLimit represents the frag limit of the game.
+	TimeLimit    int    `json:"timelimit"`    // TimeLimit represents the time limit of the game, in minutes.
+	CaptureLimit int    `json:"capturelimit"` // CaptureLimit represents the capture limit of the game.
+	Hostname     string `json:"hostname"`     // Hostname represents the server hostname.
+	Version      string `json:"version"`      // Version represents the server version.
+	Protocol     int    `json:"protocol"`     // Protocol represents the server protocol.
+}
+
+// newMatchConfig converts the entity.MatchConfig into a MatchConfig report.
+func newMatchConfig(config entity.MatchConfig) MatchConfig {
+	return MatchConfig{
+		MapName:      config.MapName,
+		GameType:     config.GameType.String(),
+		FragLimit:    config.FragLimit,
+		TimeLimit:    config.TimeLimit,
+		CaptureLimit: config.CaptureLimit,
+		Hostname:     config.Hostname,
+		Version:      config.Version,
+		Protocol:     config.Protocol,
+	}
 }
 
 // QuakeDataReport represents a collection of Quake game reports.
 type QuakeDataReport map[string]Report
+
+// Aggregated returns the report with only the fields used by the aggregations across the games, like the careers and the ratings,
+// so the reports of all the games can be kept in memory without their timeline, chat and other fields only written in the game report.
+func (r Report) Aggregated() Report {
+	aggregated := Report{
+		GameName:           r.GameName,
+		TotalKills:         r.TotalKills,
+		Players:            r.Players,
+		Kills:              r.Kills,
+		KillsByMeans:       r.KillsByMeans,
+		MatchConfig:        r.MatchConfig,
+		EndReason:          r.EndReason,
+		Complete:           r.Complete,
+		StartTime:          r.StartTime,
+		EndTime:            r.EndTime,
+		OfficialScoreboard: r.OfficialScoreboard,
+		Teams:              r.Teams,
+		TeamKills:          r.TeamKills,
+		PlayerStats:        r.PlayerStats,
+		HeadToHead:         r.HeadToHead,
+		Moderation:         r.Moderation,
+		Fingerprint:        r.Fingerprint,
+	}
+
+	if r.CTF != nil {
+		aggregated.CTF = &CTFReport{Players: r.CTF.Players}
+	}
+	if r.Items != nil {
+		aggregated.Items = &ItemsReport{ByPlayer: r.Items.ByPlayer}
+	}
+
+	return aggregated
+}
@@ -1,10 +1,12 @@
 package dto_test
 
 import (
+	"reflect"
 	"slices"
 	"testing"
 
 	"github.com/diegoclair/log-parser/application/dto"
+	"github.com/diegoclair/log-parser/domain/entity"
 )
 
 func TestQuakeData_ToReport(t *testing.T) {
@@ -20,6 +22,19 @@ func TestQuakeData_ToReport(t *testing.T) {
 			"MOD_TRIGGER_HURT": 8,
 			"MOD_FALLING":      2,
 		},
+		Config: entity.MatchConfig{
+			MapName:  "q3dm17",
+			GameType: entity.GameTypeTDM,
+		},
+		EndReason: entity.EndReasonFragLimit,
+		StartTime: entity.GameTime{Minutes: 20, Seconds: 37},
+		EndTime:   entity.GameTime{Minutes: 26, Seconds: 9},
+		Scoreboard: []dto.ScoreLine{
+			{ScoreEvent: entity.ScoreEvent{Score: 5, Ping: 4, ClientID: 0, Name: "Player1"}, PlayerID: 0},
+		},
+		Discrepancies: []dto.ScoreDiscrepancy{
+			{Player: "Player1", Computed: 5, Official: 6},
+		},
 	}
 
 	gameName := "game_test"
@@ -36,6 +51,21 @@ func TestQuakeData_ToReport(t *testing.T) {
 			"MOD_TRIGGER_HURT": 8,
 			"MOD_FALLING":      2,
 		},
+		MatchConfig: dto.MatchConfig{
+			MapName:  "q3dm17",
+			GameType: "TDM",
+		},
+		EndReason: "fraglimit",
+		Complete:  true,
+		StartTime: "20:37",
+		EndTime:   "26:09",
+		Duration:  332,
+		OfficialScoreboard: []dto.ScoreboardEntry{
+			{Name: "Player1", Player: "Player1", Score: 5, Ping: 4, ClientID: 0},
+		},
+		Discrepancies: []dto.ScoreDiscrepancy{
+			{Player: "Player1", Computed: 5, Official: 6},
+		},
 	}
 
 	got := quakeData.ToReport(gameName)
@@ -77,4 +107,168 @@ func TestQuakeData_ToReport(t *testing.T) {
 			t.Errorf("ToReport() got.KillsByMeans[%s] = %v, want.KillsByMeans[%s] %v", means, count, means, want.KillsByMeans[means])
 		}
 	}
+
+	if got.MatchConfig != want.MatchConfig {
+		t.Errorf("ToReport() got.MatchConfig = %v, want.MatchConfig %v", got.MatchConfig, want.MatchConfig)
+	}
+
+	if got.EndReason != want.EndReason {
+		t.Errorf("ToReport() got.EndReason = %v, want.EndReason %v", got.EndReason, want.EndReason)
+	}
+
+	if got.Complete != want.Complete {
+		t.Errorf("ToReport() got.Complete = %v, want.Complete %v", got.Complete, want.Complete)
+	}
+
+	if got.StartTime != want.StartTime || got.EndTime != want.EndTime || got.Duration != want.Duration {
+		t.Errorf("ToReport() got times = %v %v %v, want times %v %v %v", got.StartTime, got.EndTime, got.Duration, want.StartTime, want.EndTime, want.Duration)
+	}
+
+	if !slices.Equal(got.OfficialScoreboard, want.OfficialScoreboard) {
+		t.Errorf("ToReport() got.OfficialScoreboard = %v, want.OfficialScoreboard %v", got.OfficialScoreboard, want.OfficialScoreboard)
+	}
+
+	if !slices.Equal(got.Discrepancies, want.Discrepancies) {
+		t.Errorf("ToReport() got.Discrepancies = %v, want.Discrepancies %v", got.Discrepancies, want.Discrepancies)
+	}
+}
+
+func TestQuakeData_ToReport_Teams(t *testing.T) {
+	quakeData := dto.QuakeData{}
+	quakeData.Reset()
+	quakeData.Config.GameType = entity.GameTypeCTF
+	quakeData.Players = map[int]string{0: "Player1", 1: "Player2", 2: "Player3", 3: "Player4"}
+	quakeData.Teams = map[int]entity.Team{0: entity.TeamBlue, 1: entity.TeamRed, 2: entity.TeamBlue, 3: entity.TeamSpectator}
+	quakeData.Kills = map[int]int{0: 2, 1: 3, 2: -1}
+	quakeData.TeamKills = map[int]int{2: 1}
+	quakeData.TeamScore = &entity.TeamScoreEvent{Red: 8, Blue: 6}
+
+	got := quakeData.ToReport("game_test")
+
+	red, blue := got.Teams["red"], got.Teams["blue"]
+	if !slices.Equal(red.Players, []string{"Player2"}) || red.Kills != 3 || red.TeamKills != 0 || *red.Score != 8 {
+		t.Errorf("ToReport() got.Teams[red] = %v", red)
+	}
+
+	if !slices.Equal(blue.Players, []string{"Player1", "Player3"}) || blue.Kills != 1 || blue.TeamKills != 1 || *blue.Score != 6 {
+		t.Errorf("ToReport() got.Teams[blue] = %v", blue)
+	}
+
+	if got.TeamKills["Player3"] != 1 || len(got.TeamKills) != 1 {
+		t.Errorf("ToReport() got.TeamKills = %v", got.TeamKills)
+	}
+}
+
+func TestCTFStats_Add(t *testing.T) {
+	stats := dto.CTFStats{}
+	for _, action := range []entity.CTFAction{entity.CTFActionPickup, entity.CTFActionCapture, entity.CTFActionReturn, entity.CTFActionDefend, entity.CTFActionPickup} {
+		stats = stats.Add(action)
+	}
+
+	want := dto.CTFStats{Pickups: 2, Captures: 1, Returns: 1, Defenses: 1}
+	if stats != want {
+		t.Errorf("Add() got = %v, want %v", stats, want)
+	}
+}
+
+func TestQuakeData_ToReport_Items(t *testing.T) {
+	quakeData := dto.QuakeData{}
+	quakeData.Reset()
+	quakeData.StartTime = entity.GameTime{Minutes: 1, Seconds: 0}
+	quakeData.Players = map[int]string{0: "Player1", 1: "Player2"}
+	quakeData.ItemsByPlayer = map[int]map[entity.ItemClass]int{
+		0: {entity.ItemClassWeapon: 2},
+		1: {entity.ItemClassWeapon: 1, entity.ItemClassPowerup: 1},
+	}
+	quakeData.ItemsByName = map[string]int{"weapon_railgun": 3, "item_haste": 1}
+	quakeData.Powerups = []entity.ItemEvent{{Time: entity.GameTime{Minutes: 2, Seconds: 5}, ClientID: 1, Item: "item_haste"}}
+
+	got := quakeData.ToReport("game_test")
+	if got.Items == nil {
+		t.Fatalf("ToReport() got.Items = nil")
+	}
+
+	if got.Items.ByClass["weapon"] != 3 || got.Items.ByClass["powerup"] != 1 {
+		t.Errorf("ToReport() got.Items.ByClass = %v", got.Items.ByClass)
+	}
+
+	if got.Items.ByPlayer["Player2"]["powerup"] != 1 || got.Items.ByPlayer["Player1"]["weapon"] != 2 {
+		t.Errorf("ToReport() got.Items.ByPlayer = %v", got.Items.ByPlayer)
+	}
+
+	wantPowerups := []dto.PowerupPickup{{Player: "Player2", Item: "item_haste", Time: "2:05", Elapsed: 65}}
+	if !slices.Equal(got.Items.Powerups, wantPowerups) {
+		t.Errorf("ToReport() got.Items.Powerups = %v, want %v", got.Items.Powerups, wantPowerups)
+	}
+}
+
+func TestQuakeData_GetPlayerStats(t *testing.T) {
+	quakeData := dto.QuakeData{}
+	quakeData.Reset()
+	quakeData.Players = map[int]string{0: "Player1"}
+
+	quakeData.GetPlayerStats(0).KillsByMeans["MOD_RAILGUN"]++
+	quakeData.GetPlayerStats(0).KillsByMeans["MOD_RAILGUN"]++
+	quakeData.GetPlayerStats(0).DeathsByMeans["MOD_FALLING"]++
+	quakeData.GetPlayerStats(5).DeathsByMeans["MOD_FALLING"]++ // slot without a known player
+
+	got := quakeData.ToReport("game_test")
+
+	if len(got.PlayerStats) != 1 {
+		t.Fatalf("ToReport() got.PlayerStats length = %v, want 1", len(got.PlayerStats))
+	}
+
+	stats := got.PlayerStats["Player1"]
+	if stats.KillsByMeans["MOD_RAILGUN"] != 2 || stats.DeathsByMeans["MOD_FALLING"] != 1 {
+		t.Errorf("ToReport() got.PlayerStats[Player1] = %v", stats)
+	}
+}
+
+func TestQuakeData_ToReport_PlayerStats(t *testing.T) {
+	quakeData := dto.QuakeData{}
+	quakeData.Reset()
+	quakeData.Players = map[int]string{2: "Player1", 3: "Player2"}
+	quakeData.TeamKills = map[int]int{2: 1}
+
+	player1 := quakeData.GetPlayerStats(2)
+	player1.Frags, player1.Deaths, player1.Suicides, player1.WorldDeaths = 5, 3, 1, 1
+	quakeData.GetPlayerStats(3).Frags = 2
+
+	got := quakeData.ToReport("game_test")
+
+	want := map[string]dto.PlayerStats{
+		"Player1": {Frags: 5, Deaths: 3, Suicides: 1, WorldDeaths: 1, NetScore: 2, KDRatio: 1.67, KillsByMeans: map[string]int{}, DeathsByMeans: map[string]int{}},
+		"Player2": {Frags: 2, NetScore: 2, KDRatio: 2, KillsByMeans: map[string]int{}, DeathsByMeans: map[string]int{}},
+	}
+	if !reflect.DeepEqual(got.PlayerStats, want) {
+		t.Errorf("ToReport() got.PlayerStats = %v, want %v", got.PlayerStats, want)
+	}
+}
+
+func TestReport_Aggregated(t *testing.T) {
+	report := dto.Report{
+		GameName:    "game_001",
+		Complete:    true,
+		Players:     []string{"Player1", "Player2"},
+		Kills:       map[string]int{"Player1": 3, "Player2": 1},
+		PlayerStats: map[string]dto.PlayerStats{"Player1": {Frags: 3}},
+		Items:       &dto.ItemsReport{ByPlayer: map[string]map[string]int{"Player1": {"weapon": 1}}, ByItem: map[string]int{"weapon_railgun": 1}},
+		Chat:        []dto.ChatMessage{{Player: "Player1", Message: "gg"}},
+		Timeline:    []dto.TimelineEvent{{Time: "0:01"}},
+		Fingerprint: "3f2a9c",
+	}
+
+	got := report.Aggregated()
+	if got.Timeline != nil || got.Chat != nil || got.Items.ByItem != nil {
+		t.Errorf("Aggregated() kept the fields only written in the game report: %+v", got)
+	}
+
+	// the aggregations across the games are the same with the full reports
+	full, aggregated := dto.QuakeDataReport{"game_001": report}, dto.QuakeDataReport{"game_001": got}
+	if !reflect.DeepEqual(aggregated.Careers(), full.Careers()) {
+		t.Errorf("Aggregated() careers = %v, want %v", aggregated.Careers(), full.Careers())
+	}
+	if got.Fingerprint != report.Fingerprint {
+		t.Errorf("Aggregated() fingerprint = %v, want %v", got.Fingerprint, report.Fingerprint)
+	}
 }
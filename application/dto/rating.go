@@ -0,0 +1,190 @@
+package dto
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
+const (
+	InitialRating = 1500.0 // InitialRating is the rating of a player before the first game.
+	RatingKFactor = 32.0   // RatingKFactor is the maximum rating change of a player in a game.
+)
+
+// Rating represents the report structure for the skill rating of a player.
+type Rating struct {
+	Rating  float64 `json:"rating"`  // Rating represents the Elo rating of the player.
+	Matches int     `json:"matches"` // Matches represents the number of rated games of the player.
+}
+
+// RatingTable represents the mapping of player names to their rating, which can be persisted between runs.
+type RatingTable map[string]Rating
+
+// RatingState represents the rating table persisted between runs, with the fingerprints of the games already rated,
+// so a log parsed again does not change the ratings twice.
+type RatingState struct {
+	Ratings    RatingTable `json:"ratings"`     // Ratings represents the rating table.
+	RatedGames []string    `json:"rated_games"` // RatedGames represents the fingerprints of the rated games, in the order they were rated.
+}
+
+// NewRatingState creates a rating state without ratings and rated games.
+func NewRatingState() *RatingState {
+	return &RatingState{Ratings: make(RatingTable), RatedGames: make([]string, 0)}
+}
+
+// RatingChange represents the report structure for the rating of a player after a game.
+type RatingChange struct {
+	Game   string  `json:"game"`   // Game represents the game name.
+	Rating float64 `json:"rating"` // Rating represents the rating of the player after the game.
+	Change float64 `json:"change"` // Change represents the rating won or lost in the game.
+}
+
+// RatingHistory represents the mapping of player names to their rating after each game, in log order.
+type RatingHistory map[string][]RatingChange
+
+// RatingsReport represents the report structure for the ratings of the players.
+type RatingsReport struct {
+	Ratings RatingTable   `json:"ratings"` // Ratings represents the rating table after all the games.
+	History RatingHistory `json:"history"` // History represents the rating history of each player.
+}
+
+// GameNames returns the names of the games in log order.
+func (r QuakeDataReport) GameNames() []string {
+	names := make([]string, 0, len(r))
+	for name := range r {
+		names = append(names, name)
+	}
+
+	// the game number is zero padded to three digits, so the longer names come after
+	sort.Slice(names, func(i, j int) bool {
+		if len(names[i]) != len(names[j]) {
+			return len(names[i]) < len(names[j])
+		}
+		return names[i] < names[j]
+	})
+
+	return names
+}
+
+// UpdateRatings updates the rating state with the placements of each game, in log order, and returns the rating history of the players.
+// The rating is a multiplayer Elo: each player plays a duel against every opponent of the game, won by the best placement,
+// and the change of the duels is averaged so a game is worth at most RatingKFactor points.
+// Games that were not played until the end, games with less than two players with a score and games already rated are not rated.
+func (r QuakeDataReport) UpdateRatings(state *RatingState) RatingHistory {
+	history := make(RatingHistory)
+	if state.Ratings == nil {
+		state.Ratings = make(RatingTable)
+	}
+	table := state.Ratings
+
+	rated := make(map[string]bool, len(state.RatedGames))
+	for _, fingerprint := range state.RatedGames {
+		rated[fingerprint] = true
+	}
+
+	for _, name := range r.GameNames() {
+		report := r[name]
+		if !report.Complete {
+			continue
+		}
+
+		if rated[report.Fingerprint] {
+			continue
+		}
+
+		// the players without a score, like the ones who left before the scoreboard, are not rated
+		players := make([]string, 0, len(report.Players))
+		placements := make(map[string]int)
+		for _, player := range report.Players {
+			if report.scored(player) {
+				players = append(players, player)
+				placements[player] = report.placement(player)
+			}
+		}
+
+		if len(players) < 2 {
+			continue
+		}
+
+		for _, player := range players {
+			if _, ok := table[player]; !ok {
+				table[player] = Rating{Rating: InitialRating}
+			}
+		}
+
+		// the changes are computed from the ratings before the game, so the order of the players does not matter
+		changes := make(map[string]float64)
+		for _, player := range players {
+			for _, opponent := range players {
+				if player == opponent {
+					continue
+				}
+
+				expected := 1 / (1 + math.Pow(10, (table[opponent].Rating-table[player].Rating)/400))
+				changes[player] += duelScore(placements[player], placements[opponent]) - expected
+			}
+		}
+
+		for _, player := range players {
+			change := roundRating(RatingKFactor * changes[player] / float64(len(players)-1))
+			rating := table[player]
+			rating.Rating = roundRating(rating.Rating + change)
+			rating.Matches++
+			table[player] = rating
+
+			history[player] = append(history[player], RatingChange{Game: name, Rating: rating.Rating, Change: change})
+		}
+
+		rated[report.Fingerprint] = true
+		state.RatedGames = append(state.RatedGames, report.Fingerprint)
+	}
+
+	return history
+}
+
+// Fingerprint returns a hash of the server settings, the times, the names and the kills by mean of the game, which identifies the game
+// across runs, as the game names only number the games of a run.
+// The names are the ones printed by the server and the means are not grouped, so the aliases and the weapon grouping do not change it.
+func (q *QuakeData) Fingerprint() string {
+	names := make([]string, 0, len(q.NameHistory))
+	for _, history := range q.NameHistory {
+		names = append(names, history...)
+	}
+	slices.Sort(names)
+
+	// the maps are encoded with sorted keys, so the same game always has the same encoding
+	content, _ := json.Marshal(struct {
+		MatchConfig  MatchConfig
+		StartTime    string
+		EndTime      string
+		EndReason    entity.EndReason
+		TotalKills   int
+		Names        []string
+		KillsByMeans map[string]int
+	}{newMatchConfig(q.Config), q.StartTime.String(), q.EndTime.String(), q.EndReason, q.TotalKills, slices.Compact(names), q.KillsByMeans})
+
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:16])
+}
+
+// duelScore returns the Elo score of a player against an opponent: 1 for a better placement, 0.5 for a tie and 0 for a worse one.
+func duelScore(placement, opponentPlacement int) float64 {
+	switch {
+	case placement < opponentPlacement:
+		return 1
+	case placement == opponentPlacement:
+		return 0.5
+	}
+
+	return 0
+}
+
+// roundRating rounds the rating to two decimals.
+func roundRating(rating float64) float64 {
+	return math.Round(rating*100) / 100
+}
@@ -0,0 +1,114 @@
+package dto_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/diegoclair/log-parser/application/dto"
+)
+
+func TestQuakeDataReport_GameNames(t *testing.T) {
+	reports := dto.QuakeDataReport{"game_1000": {}, "game_002": {}, "game_999": {}, "game_001": {}}
+
+	want := []string{"game_001", "game_002", "game_999", "game_1000"}
+	if got := reports.GameNames(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GameNames() got = %v, want %v", got, want)
+	}
+}
+
+func TestQuakeDataReport_UpdateRatings(t *testing.T) {
+	reports := dto.QuakeDataReport{
+		"game_001": {
+			Fingerprint: "fingerprint_001",
+			Complete:    true,
+			Players:     []string{"Player1", "Player2"},
+			Kills:       map[string]int{"Player1": 3, "Player2": 1},
+		},
+		"game_002": {
+			Fingerprint: "fingerprint_002",
+			Complete:    true,
+			Players:     []string{"Player1"},
+			Kills:       map[string]int{"Player1": 5},
+		},
+		// the games that were not played until the end are not rated
+		"game_003": {
+			Fingerprint: "fingerprint_003",
+			Players:     []string{"Player1", "Player2"},
+			Kills:       map[string]int{"Player1": 1, "Player2": 9},
+		},
+		"game_004": {
+			Fingerprint: "fingerprint_004",
+			Complete:    true,
+			Players:     []string{"Player1", "Player2", "Player3"},
+			Kills:       map[string]int{"Player1": 1, "Player2": 1},
+			// the official scoreboard is used for the placements when the server printed it
+			OfficialScoreboard: []dto.ScoreboardEntry{
+				{Name: "Player3", Player: "Player3", Score: 2},
+				{Name: "Player1", Player: "Player1", Score: 1},
+				{Name: "Player2", Player: "Player2", Score: 1},
+			},
+		},
+	}
+
+	state := &dto.RatingState{Ratings: dto.RatingTable{"Player3": {Rating: 1600, Matches: 10}}}
+
+	history := reports.UpdateRatings(state)
+
+	wantTable := dto.RatingTable{
+		"Player1": {Rating: 1509.16, Matches: 2},
+		"Player2": {Rating: 1479.31, Matches: 2},
+		"Player3": {Rating: 1611.53, Matches: 11},
+	}
+	if !reflect.DeepEqual(state.Ratings, wantTable) {
+		t.Errorf("UpdateRatings() table = %v, want %v", state.Ratings, wantTable)
+	}
+
+	wantHistory := dto.RatingHistory{
+		"Player1": {{Game: "game_001", Rating: 1516, Change: 16}, {Game: "game_004", Rating: 1509.16, Change: -6.84}},
+		"Player2": {{Game: "game_001", Rating: 1484, Change: -16}, {Game: "game_004", Rating: 1479.31, Change: -4.69}},
+		"Player3": {{Game: "game_004", Rating: 1611.53, Change: 11.53}},
+	}
+	if !reflect.DeepEqual(history, wantHistory) {
+		t.Errorf("UpdateRatings() history = %v, want %v", history, wantHistory)
+	}
+
+	wantRated := []string{"fingerprint_001", "fingerprint_004"}
+	if !reflect.DeepEqual(state.RatedGames, wantRated) {
+		t.Errorf("UpdateRatings() rated games = %v, want %v", state.RatedGames, wantRated)
+	}
+
+	// the games already rated are skipped, so parsing the same log again does not change the ratings
+	if history := reports.UpdateRatings(state); len(history) != 0 {
+		t.Errorf("UpdateRatings() history of the second run = %v, want empty", history)
+	}
+	if !reflect.DeepEqual(state.Ratings, wantTable) {
+		t.Errorf("UpdateRatings() table of the second run = %v, want %v", state.Ratings, wantTable)
+	}
+}
+
+func TestQuakeData_Fingerprint(t *testing.T) {
+	quakeData := dto.QuakeData{
+		TotalKills:   3,
+		NameHistory:  map[int][]string{2: {"Player1", "P1"}, 3: {"Player2"}},
+		KillsByMeans: map[string]int{"MOD_ROCKET": 2, "MOD_ROCKET_SPLASH": 1},
+	}
+
+	// the player IDs do not change the fingerprint, as they depend on the aliases
+	same := dto.QuakeData{
+		TotalKills:   3,
+		NameHistory:  map[int][]string{1024: {"Player2"}, 2: {"P1", "Player1"}},
+		KillsByMeans: map[string]int{"MOD_ROCKET": 2, "MOD_ROCKET_SPLASH": 1},
+	}
+	if quakeData.Fingerprint() != same.Fingerprint() {
+		t.Errorf("Fingerprint() = %v, want %v", same.Fingerprint(), quakeData.Fingerprint())
+	}
+
+	other := dto.QuakeData{
+		TotalKills:   3,
+		NameHistory:  map[int][]string{2: {"Player1", "P1"}, 3: {"Player2"}},
+		KillsByMeans: map[string]int{"MOD_ROCKET": 3},
+	}
+	if quakeData.Fingerprint() == other.Fingerprint() {
+		t.Errorf("Fingerprint() = %v for different games", other.Fingerprint())
+	}
+}
@@ -0,0 +1,11 @@
+package dto
+
+// GameSegment represents the lines of one game of the log, from its InitGame line to the line before the next InitGame
+// or to the end of its log file. The lines of a log file before its first game are at the start of the segment of that game,
+// and the lines before the first game of the log are sent as the segment of game 0, even if there are none,
+// so the segments of a log are numbered without gaps.
+type GameSegment struct {
+	Game  int       // Game is the number of the game in the log, starting at 1, or 0 for the lines before the first game.
+	Lines []LogLine // Lines are the lines of the game, in log order.
+	Last  bool      // Last is true for the segment at the end of a log file, whose game was still in progress when the file ended.
+}
@@ -0,0 +1,38 @@
+package dto
+
+// TimelineEventType represents the kind of event of a match timeline.
+type TimelineEventType string
+
+const (
+	TimelineEventConnect    TimelineEventType = "connect"     // A client connected to a slot, the player is not known yet.
+	TimelineEventJoin       TimelineEventType = "join"        // A player got a name and a team for the first time in the match.
+	TimelineEventTeamChange TimelineEventType = "team_change" // A player changed team.
+	TimelineEventBegin      TimelineEventType = "begin"       // A player entered the game.
+	TimelineEventDisconnect TimelineEventType = "disconnect"  // A player left the server.
+	TimelineEventItem       TimelineEventType = "item"        // A player picked up an item.
+	TimelineEventKill       TimelineEventType = "kill"        // A player, or <world>, killed a player.
+	TimelineEventCTF        TimelineEventType = "ctf"         // A player performed a capture the flag action.
+	TimelineEventChat       TimelineEventType = "chat"        // A player sent a chat message.
+	TimelineEventExit       TimelineEventType = "exit"        // The match hit a limit.
+	TimelineEventShutdown   TimelineEventType = "shutdown"    // The server shut the match down.
+	TimelineEventScore      TimelineEventType = "score"       // The final score of a player, from the scoreboard.
+	TimelineEventTeamScore  TimelineEventType = "team_score"  // The final score of a team, from the scoreboard.
+)
+
+// TimelineEvent represents the report structure for an event of a match timeline.
+// Only the fields of the event type are set.
+type TimelineEvent struct {
+	Time    string            `json:"time"`              // Time represents the server time of the event, in the mm:ss log format.
+	Elapsed int               `json:"elapsed"`           // Elapsed represents the seconds since the start of the match.
+	Type    TimelineEventType `json:"type"`              // Type represents the kind of event.
+	Slot    *int              `json:"slot,omitempty"`    // Slot represents the client slot of the connect events.
+	Player  string            `json:"player,omitempty"`  // Player represents the name of the player, the killer on the kill events.
+	Victim  string            `json:"victim,omitempty"`  // Victim represents the name of the killed player.
+	Mean    string            `json:"mean,omitempty"`    // Mean represents the MOD_* mean of the kill.
+	Team    string            `json:"team,omitempty"`    // Team represents the team of the player, or of the team score.
+	Item    string            `json:"item,omitempty"`    // Item represents the name of the picked up item.
+	Action  string            `json:"action,omitempty"`  // Action represents the capture the flag action, like "capture".
+	Message string            `json:"message,omitempty"` // Message represents the text of the chat message.
+	Reason  string            `json:"reason,omitempty"`  // Reason represents the end reason of the exit events, like "timelimit".
+	Score   *int              `json:"score,omitempty"`   // Score represents the score of the player or the team.
+}
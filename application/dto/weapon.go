@@ -0,0 +1,25 @@
+package dto
+
+import "github.com/diegoclair/log-parser/domain/entity"
+
+// GroupByWeapon replaces the MOD_* means of the kills and deaths of the game by their weapon, summing the means of the same weapon.
+// The means that are not in the catalogue are kept by name.
+func (r *Report) GroupByWeapon() {
+	r.KillsByMeans = groupByWeapon(r.KillsByMeans)
+
+	for player, stats := range r.PlayerStats {
+		stats.KillsByMeans = groupByWeapon(stats.KillsByMeans)
+		stats.DeathsByMeans = groupByWeapon(stats.DeathsByMeans)
+		r.PlayerStats[player] = stats
+	}
+}
+
+// groupByWeapon returns the counts of the means summed by weapon.
+func groupByWeapon(byMeans map[string]int) map[string]int {
+	byWeapon := make(map[string]int, len(byMeans))
+	for mean, count := range byMeans {
+		byWeapon[entity.WeaponOf(mean)] += count
+	}
+
+	return byWeapon
+}
@@ -0,0 +1,41 @@
+package dto_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/diegoclair/log-parser/application/dto"
+)
+
+func TestReport_GroupByWeapon(t *testing.T) {
+	report := dto.Report{
+		KillsByMeans: map[string]int{"MOD_ROCKET": 2, "MOD_ROCKET_SPLASH": 3, "MOD_FALLING": 1, "MOD_CUSTOM": 1},
+		PlayerStats: map[string]dto.PlayerStats{
+			"Player1": {
+				KillsByMeans:  map[string]int{"MOD_ROCKET": 2, "MOD_ROCKET_SPLASH": 3, "MOD_CUSTOM": 1},
+				DeathsByMeans: map[string]int{"MOD_FALLING": 1, "MOD_TRIGGER_HURT": 2},
+			},
+		},
+	}
+
+	report.GroupByWeapon()
+
+	wantKills := map[string]int{"rocket_launcher": 5, "world": 1, "MOD_CUSTOM": 1}
+	if !reflect.DeepEqual(report.KillsByMeans, wantKills) {
+		t.Errorf("GroupByWeapon() kills by means = %v, want %v", report.KillsByMeans, wantKills)
+	}
+
+	wantStats := dto.PlayerStats{
+		KillsByMeans:  map[string]int{"rocket_launcher": 5, "MOD_CUSTOM": 1},
+		DeathsByMeans: map[string]int{"world": 3},
+	}
+	if !reflect.DeepEqual(report.PlayerStats["Player1"], wantStats) {
+		t.Errorf("GroupByWeapon() player stats = %v, want %v", report.PlayerStats["Player1"], wantStats)
+	}
+
+	// the award rules still name the means, which are matched by their weapon without counting it twice
+	awards := report.EvaluateAwards(awardRules[:1])
+	if len(awards) != 1 || awards[0].Value != 5 {
+		t.Errorf("EvaluateAwards() got = %v, want the Rocket man award with 5 kills", awards)
+	}
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"github.com/diegoclair/log-parser/application/dto"
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
+// processSayEvent is a function that processes the say event and records the chat message with the words flagged by the word filter.
+func processSayEvent(event entity.SayEvent, gameData *dto.QuakeData, filter entity.WordFilter) {
+	gameData.Chat = append(gameData.Chat, dto.ChatMessage{
+		Player:   event.Name,
+		Time:     event.Time.String(),
+		Message:  event.Message,
+		TeamOnly: event.TeamOnly,
+		Flagged:  filter.Match(event.Message),
+	})
+}
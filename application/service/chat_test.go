@@ -0,0 +1,74 @@
+package service_test
+
+import (
+	"github.com/diegoclair/log-parser/application/dto"
+	"github.com/diegoclair/log-parser/domain/entity"
+	"github.com/diegoclair/log-parser/infra/config"
+)
+
+var processChatEventTests = []test{
+	{
+		name: "should report the chat and the messages flagged by the word list",
+		cfg: &config.Config{
+			Chat:       true,
+			Aliases:    map[string]string{"T1": "Test1"},
+			WordFilter: entity.NewWordFilter([]string{"noob"}),
+		},
+		args: args{
+			lines: []string{
+				initGameEvent,
+				userTest1Event,
+				`20:40 say: T1: gg`,
+				`20:45 sayteam: Test1: that NOOB again`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:     "game_001",
+				EndReason:    "truncated",
+				StartTime:    "0:00",
+				EndTime:      "20:45",
+				Duration:     1245,
+				MatchConfig:  initGameMatchConfig,
+				Players:      []string{"Test1"},
+				Kills:        make(map[string]int),
+				KillsByMeans: make(map[string]int),
+				Chat: []dto.ChatMessage{
+					{Player: "Test1", Time: "20:40", Message: "gg"},
+					{Player: "Test1", Time: "20:45", Message: "that NOOB again", TeamOnly: true, Flagged: []string{"noob"}},
+				},
+				Moderation: dto.ModerationReport{
+					"Test1": {{Time: "20:45", Message: "that NOOB again", Words: []string{"noob"}}},
+				},
+			},
+		},
+	},
+	{
+		name: "should report the moderation without the chat if the chat is not enabled",
+		cfg:  &config.Config{WordFilter: entity.NewWordFilter([]string{"noob"})},
+		args: args{
+			lines: []string{
+				initGameEvent,
+				userTest1Event,
+				`20:40 say: Test1: noob`,
+				`20:41 say: Test1: gg`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:     "game_001",
+				EndReason:    "truncated",
+				StartTime:    "0:00",
+				EndTime:      "20:41",
+				Duration:     1241,
+				MatchConfig:  initGameMatchConfig,
+				Players:      []string{"Test1"},
+				Kills:        make(map[string]int),
+				KillsByMeans: make(map[string]int),
+				Moderation: dto.ModerationReport{
+					"Test1": {{Time: "20:40", Message: "noob", Words: []string{"noob"}}},
+				},
+			},
+		},
+	},
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"github.com/diegoclair/log-parser/application"
+	"github.com/diegoclair/log-parser/application/dto"
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
+// processCTFEvent is a function that processes the CTF event and updates the capture the flag stats.
+// The first CTF line of a game discards the stats inferred from items, as the server lines are authoritative.
+func processCTFEvent(event entity.CTFEvent, gameData *dto.QuakeData) {
+	if !gameData.CTFFromLog {
+		gameData.ResetCTF()
+		gameData.CTFFromLog = true
+	}
+
+	addCTFAction(event.ClientID, event.Team, event.Action, gameData)
+}
+
+// processFlagItemEvent is a function that infers the capture the flag action of a player touching a flag.
+// Touching the enemy flag is a pickup, touching the own flag while carrying the enemy one is a capture
+// and touching the own flag otherwise is a return, because the server does not log touches on a flag at its base.
+func processFlagItemEvent(playerID int, flagTeam entity.Team, gameData *dto.QuakeData) {
+	team := gameData.Teams[playerID]
+	if gameData.CTFFromLog || !team.IsPlaying() {
+		return
+	}
+
+	if flagTeam != team {
+		gameData.FlagCarriers[flagTeam] = playerID
+		addCTFAction(playerID, team, entity.CTFActionPickup, gameData)
+		return
+	}
+
+	enemyFlag := team.Opponent()
+	if carrierID, ok := gameData.FlagCarriers[enemyFlag]; ok && carrierID == playerID {
+		delete(gameData.FlagCarriers, enemyFlag)
+		addCTFAction(playerID, team, entity.CTFActionCapture, gameData)
+		return
+	}
+
+	addCTFAction(playerID, team, entity.CTFActionReturn, gameData)
+}
+
+// processFlagCarrierKill is a function that drops the flag of a killed carrier.
+// If the carrier was killed by a player of the flag team, it counts as a defense for the killer.
+func processFlagCarrierKill(event entity.KillEvent, gameData *dto.QuakeData) {
+	for flagTeam, carrierID := range gameData.FlagCarriers {
+		if carrierID != event.KilledID {
+			continue
+		}
+
+		delete(gameData.FlagCarriers, flagTeam)
+
+		killerTeam := gameData.Teams[event.KillerID]
+		if gameData.CTFFromLog || event.KillerID == application.WorldPlayerID || killerTeam != flagTeam {
+			continue
+		}
+
+		addCTFAction(event.KillerID, killerTeam, entity.CTFActionDefend, gameData)
+	}
+}
+
+// addCTFAction increments the action counter of the player and of the team.
+func addCTFAction(playerID int, team entity.Team, action entity.CTFAction, gameData *dto.QuakeData) {
+	gameData.CTFPlayers[playerID] = gameData.CTFPlayers[playerID].Add(action)
+	gameData.CTFTeams[team] = gameData.CTFTeams[team].Add(action)
+}
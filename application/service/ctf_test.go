@@ -0,0 +1,122 @@
+package service_test
+
+import "github.com/diegoclair/log-parser/application/dto"
+
+var ctfInitGameEvent = `  0:00 InitGame: \g_gametype\4`
+
+var processCTFEventTests = []test{
+	{
+		name: "should infer the capture the flag stats from the flag items and the carrier kills",
+		args: args{
+			lines: []string{
+				ctfInitGameEvent,
+				userRedTest1Event,
+				userBlueTest2Event,
+				userRedTest3Event,
+				`10:00 Item: 2 team_CTF_blueflag`,
+				`10:01 Kill: 3 2 7: Test2 killed Test1 by MOD_ROCKET_SPLASH`,
+				`10:02 Item: 3 team_CTF_blueflag`,
+				`10:03 Item: 4 team_CTF_blueflag`,
+				`10:04 Item: 4 team_CTF_redflag`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:    "game_001",
+				EndReason:   "truncated",
+				StartTime:   "0:00",
+				EndTime:     "10:04",
+				Duration:    604,
+				MatchConfig: dto.MatchConfig{GameType: "CTF"},
+				TotalKills:  1,
+				Players:     []string{"Test1", "Test2", "Test3"},
+				Kills: map[string]int{
+					"Test2": 1,
+				},
+				KillsByMeans: map[string]int{
+					"MOD_ROCKET_SPLASH": 1,
+				},
+				Teams: map[string]dto.TeamReport{
+					"red":  {Players: []string{"Test1", "Test3"}},
+					"blue": {Players: []string{"Test2"}, Kills: 1},
+				},
+				CTF: &dto.CTFReport{
+					Players: map[string]dto.CTFStats{
+						"Test1": {Pickups: 1},
+						"Test2": {Returns: 1, Defenses: 1},
+						"Test3": {Pickups: 1, Captures: 1},
+					},
+					Teams: map[string]dto.CTFStats{
+						"red":  {Pickups: 2, Captures: 1},
+						"blue": {Returns: 1, Defenses: 1},
+					},
+				},
+				Items: &dto.ItemsReport{
+					ByPlayer: map[string]map[string]int{
+						"Test1": {"flag": 1},
+						"Test2": {"flag": 1},
+						"Test3": {"flag": 2},
+					},
+					ByClass:  map[string]int{"flag": 4},
+					ByItem:   map[string]int{"team_CTF_blueflag": 3, "team_CTF_redflag": 1},
+					Powerups: []dto.PowerupPickup{},
+				},
+				PlayerStats: map[string]dto.PlayerStats{
+					"Test1": {Deaths: 1, LongestLife: 601, KillsByMeans: map[string]int{}, DeathsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 1}},
+					"Test2": {Frags: 1, NetScore: 1, KDRatio: 1, LongestStreak: 1, LongestLife: 604, KillsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 1}, DeathsByMeans: map[string]int{}},
+				},
+				HeadToHead: dto.HeadToHead{
+					"Test2": {"Test1": 1},
+				},
+				FirstBlood: &dto.FirstBlood{Killer: "Test2", Victim: "Test1", Time: "10:01", Elapsed: 601},
+			},
+		},
+	},
+	{
+		name: "should use the CTF lines instead of the flag items when the server prints them",
+		args: args{
+			lines: []string{
+				ctfInitGameEvent,
+				userRedTest1Event,
+				`10:00 Item: 2 team_CTF_blueflag`,
+				`10:00 CTF: 2 1 0: Test1 got the BLUE flag!`,
+				`10:04 CTF: 2 1 1: Test1 captured the BLUE flag!`,
+				`10:04 Item: 2 team_CTF_redflag`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:     "game_001",
+				EndReason:    "truncated",
+				StartTime:    "0:00",
+				EndTime:      "10:04",
+				Duration:     604,
+				MatchConfig:  dto.MatchConfig{GameType: "CTF"},
+				Players:      []string{"Test1"},
+				Kills:        make(map[string]int),
+				KillsByMeans: make(map[string]int),
+				Teams: map[string]dto.TeamReport{
+					"red":  {Players: []string{"Test1"}},
+					"blue": {Players: []string{}},
+				},
+				CTF: &dto.CTFReport{
+					Players: map[string]dto.CTFStats{
+						"Test1": {Pickups: 1, Captures: 1},
+					},
+					Teams: map[string]dto.CTFStats{
+						"red":  {Pickups: 1, Captures: 1},
+						"blue": {},
+					},
+				},
+				Items: &dto.ItemsReport{
+					ByPlayer: map[string]map[string]int{
+						"Test1": {"flag": 2},
+					},
+					ByClass:  map[string]int{"flag": 2},
+					ByItem:   map[string]int{"team_CTF_blueflag": 1, "team_CTF_redflag": 1},
+					Powerups: []dto.PowerupPickup{},
+				},
+			},
+		},
+	},
+}
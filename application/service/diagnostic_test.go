@@ -0,0 +1,165 @@
+package service_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/diegoclair/log-parser/application/contract"
+	"github.com/diegoclair/log-parser/application/dto"
+	"github.com/diegoclair/log-parser/infra/config"
+	"github.com/stretchr/testify/require"
+)
+
+// extractData sends the lines to a new service and returns the service, the reports and the error of the extraction.
+// The lines are sent from a goroutine that stops when the service stops reading them.
+func extractData(t testing.TB, cfg *config.Config, lines []dto.LogLine) (contract.QuakeService, []dto.Report, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lineChan := make(chan dto.LogLine)
+	go func() {
+		defer close(lineChan)
+		for _, line := range lines {
+			select {
+			case lineChan <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	writerChan := make(chan dto.Report)
+	errChan := make(chan error, 1)
+	svc := getQuakeService(t, cfg)
+	go func() {
+		errChan <- svc.StartExtractingData(ctx, lineChan, writerChan)
+	}()
+
+	reports := []dto.Report{}
+	for report := range writerChan {
+		reports = append(reports, report)
+	}
+
+	return svc, reports, <-errChan
+}
+
+// logLines returns the lines of a games.log file, 100 bytes each.
+func logLines(lines ...string) []dto.LogLine {
+	logLines := make([]dto.LogLine, 0, len(lines))
+	for i, line := range lines {
+		logLines = append(logLines, dto.LogLine{Text: line, Source: "games.log", Number: i + 1, Offset: int64(i * 100)})
+	}
+
+	return logLines
+}
+
+func TestQuakeService_Diagnostics(t *testing.T) {
+	lines := []string{
+		`  0:00 Warmup: 1`,
+		initGameEvent,
+		userTest1Event,
+		` 26  0:00 ------------------------------------------------------------`,
+		`20:40 Kill: 1022 2 19: <world> killed Test1 by MOD_TRIGGER_HURT`,
+		`20:30 Item: 2 weapon_rocketlauncher`,
+		`20:50 ------------------------------------------------------------`,
+	}
+
+	svc, _, err := extractData(t, config.GetDefaultConfig(), logLines(lines...))
+	require.NoError(t, err)
+	require.Equal(t, dto.DiagnosticsReport{
+		Lines:    7,
+		Errors:   1,
+		Warnings: 3,
+		ByReason: map[dto.DiagnosticReason]int{
+			dto.DiagnosticUnknownEvent:   1,
+			dto.DiagnosticMalformedLine:  1,
+			dto.DiagnosticInvalidMean:    1,
+			dto.DiagnosticClockBackwards: 1,
+		},
+		Diagnostics: []dto.Diagnostic{
+			{
+				Source: "games.log", Line: 1, Offset: 0, Severity: dto.DiagnosticWarning, Reason: dto.DiagnosticUnknownEvent,
+				Detail: "unknown event:   0:00 Warmup: 1", Text: lines[0],
+			},
+			{
+				Source: "games.log", Line: 4, Offset: 300, Game: "game_001", Severity: dto.DiagnosticError, Reason: dto.DiagnosticMalformedLine,
+				Detail: "invalid timestamp in line:  26  0:00 ------------------------------------------------------------", Text: lines[3],
+			},
+			{
+				Source: "games.log", Line: 5, Offset: 400, Game: "game_001", Severity: dto.DiagnosticWarning, Reason: dto.DiagnosticInvalidMean,
+				Detail: "mean of death MOD_TRIGGER_HURT has the id 19 instead of 22", Text: lines[4],
+			},
+			{
+				Source: "games.log", Line: 6, Offset: 500, Game: "game_001", Severity: dto.DiagnosticWarning, Reason: dto.DiagnosticClockBackwards,
+				Detail: "timestamp 20:30 is before 20:40", Text: lines[5],
+			},
+		},
+	}, svc.Diagnostics())
+}
+
+func TestQuakeService_StartExtractingData_Strict(t *testing.T) {
+	lines := []string{
+		initGameEvent,
+		userTest1Event,
+		`20:40 Kill: 1022 2 19: <world> killed Test1 by MOD_TRIGGER_HURT`,
+		` 26  0:00 ------------------------------------------------------------`,
+		userTest2Event,
+	}
+
+	t.Run("should stop on the first malformed line in strict mode", func(t *testing.T) {
+		_, reports, err := extractData(t, &config.Config{Strict: true}, logLines(lines...))
+		require.ErrorContains(t, err, "malformed line 4")
+		require.Empty(t, reports)
+	})
+
+	t.Run("should skip the empty lines in strict mode", func(t *testing.T) {
+		svc, reports, err := extractData(t, &config.Config{Strict: true}, logLines(initGameEvent, "", userTest1Event, " \t ", ""))
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		require.Empty(t, svc.Diagnostics().Diagnostics)
+	})
+
+	t.Run("should not stop on the malformed lines without strict mode", func(t *testing.T) {
+		_, reports, err := extractData(t, &config.Config{}, logLines(lines...))
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		require.Len(t, reports[0].Players, 2)
+	})
+}
+
+func TestQuakeService_StartExtractingData_PartialLine(t *testing.T) {
+	lines := logLines(
+		initGameEvent,
+		userTest1Event,
+		` 26  0:00 ------------------------------------------------------------`,
+		`  0:05 ClientUserinfoChanged: 3 n\Test2\t\0\model\sarge/default\hmodel\`,
+		initGameEvent,
+		userTest2Event,
+	)
+
+	// the line 3 is split by the reader into the partial record and the record glued to it
+	partial, record := lines[2], lines[2]
+	partial.Text, partial.Partial = ` 26`, true
+	record.Text, record.Offset, record.Glued = `  0:00 ------------------------------------------------------------`, record.Offset+3, true
+	lines = slices.Replace(lines, 2, 3, partial, record)
+
+	svc, reports, err := extractData(t, config.GetDefaultConfig(), lines)
+	require.NoError(t, err)
+
+	// the game cut by the crash is truncated and the lines after it do not bleed into any game
+	require.Len(t, reports, 2)
+	require.Equal(t, "game_001", reports[0].GameName)
+	require.Equal(t, "truncated", reports[0].EndReason)
+	require.Equal(t, []string{"Test1"}, reports[0].Players)
+	require.Equal(t, "game_002", reports[1].GameName)
+	require.Equal(t, []string{"Test2"}, reports[1].Players)
+
+	require.Equal(t, []dto.Diagnostic{
+		{
+			Source: "games.log", Line: 3, Offset: 200, Game: "game_001", Severity: dto.DiagnosticWarning, Reason: dto.DiagnosticPartialLine,
+			Detail: "record cut by a crash of the server", Text: " 26",
+		},
+	}, svc.Diagnostics().Diagnostics)
+	require.Equal(t, 6, svc.Diagnostics().Lines)
+}
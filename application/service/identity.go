@@ -0,0 +1,105 @@
+package service
+
+import (
+	"slices"
+
+	"github.com/diegoclair/log-parser/application/dto"
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
+// reusedSlotBaseID is the first player ID given to a new player on a slot whose ID is already used by another player of the game.
+const reusedSlotBaseID = 1024
+
+// resolvePlayerIDs is a function that replaces the client slots of the event by the stable player IDs of the game.
+// The slots are bound to a player by the user info lines and released by the connect and disconnect lines,
+// so a slot reused by another player does not mix their stats, and a player who reconnects keeps them.
+func resolvePlayerIDs(event entity.Event, gameData *dto.QuakeData, aliases map[string]string) entity.Event {
+	switch e := event.(type) {
+	case entity.ClientConnectEvent:
+		delete(gameData.Slots, e.ClientID)
+	case entity.ClientDisconnectEvent:
+		slot := e.ClientID
+		e.ClientID = gameData.PlayerID(slot)
+		delete(gameData.Slots, slot)
+		return e
+	case entity.ClientUserinfoChangedEvent:
+		return bindPlayer(e, gameData, aliases)
+	case entity.ClientBeginEvent:
+		e.ClientID = gameData.PlayerID(e.ClientID)
+		return e
+	case entity.KillEvent:
+		e.KillerID = gameData.PlayerID(e.KillerID)
+		e.KilledID = gameData.PlayerID(e.KilledID)
+		return e
+	case entity.ItemEvent:
+		e.ClientID = gameData.PlayerID(e.ClientID)
+		return e
+	case entity.CTFEvent:
+		e.ClientID = gameData.PlayerID(e.ClientID)
+		return e
+	case entity.SayEvent:
+		// the say lines have the name instead of the slot, so only the alias is applied
+		if canonical, ok := aliases[e.Name]; ok {
+			e.Name = canonical
+		}
+		return e
+	}
+
+	return event
+}
+
+// bindPlayer is a function that binds the slot of the user info event to a player and records the name in the player name history.
+// A slot without player is bound to the player with the same canonical name who has no slot, who is reconnecting, or to a new player.
+func bindPlayer(event entity.ClientUserinfoChangedEvent, gameData *dto.QuakeData, aliases map[string]string) entity.ClientUserinfoChangedEvent {
+	name := event.Name
+	if canonical, ok := aliases[name]; ok {
+		name = canonical
+	}
+
+	playerID, ok := gameData.Slots[event.ClientID]
+	if !ok {
+		playerID = findPlayerID(name, event.ClientID, gameData)
+		gameData.Slots[event.ClientID] = playerID
+	}
+
+	history := gameData.NameHistory[playerID]
+	if len(history) == 0 || history[len(history)-1] != event.Name {
+		gameData.NameHistory[playerID] = append(history, event.Name)
+	}
+
+	event.ClientID, event.Name = playerID, name
+	return event
+}
+
+// findPlayerID returns the ID of the player with the name who has no slot, or a new ID that is the slot itself if no player of the game used it yet.
+// The players still bound to a slot are in the game, so a client with the same name is another player.
+// When more than one player of the game used the name, the lowest ID is chosen, so the result does not depend on the map order.
+func findPlayerID(name string, slot int, gameData *dto.QuakeData) int {
+	bound := make(map[int]bool, len(gameData.Slots))
+	for _, playerID := range gameData.Slots {
+		bound[playerID] = true
+	}
+
+	playerIDs := make([]int, 0, len(gameData.Players))
+	for playerID, playerName := range gameData.Players {
+		if playerName == name && !bound[playerID] {
+			playerIDs = append(playerIDs, playerID)
+		}
+	}
+
+	if len(playerIDs) > 0 {
+		return slices.Min(playerIDs)
+	}
+
+	if _, ok := gameData.Players[slot]; !ok {
+		return slot
+	}
+
+	playerID := reusedSlotBaseID
+	for {
+		if _, ok := gameData.Players[playerID]; !ok {
+			return playerID
+		}
+		playerID++
+	}
+}
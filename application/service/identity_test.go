@@ -0,0 +1,167 @@
+package service_test
+
+import (
+	"github.com/diegoclair/log-parser/application/dto"
+	"github.com/diegoclair/log-parser/infra/config"
+)
+
+var processIdentityEventTests = []test{
+	{
+		name: "should keep the stats of a player who renames and report the name history",
+		args: args{
+			lines: []string{
+				initGameEvent,
+				userTest1Event,
+				`20:35 Item: 2 weapon_rocketlauncher`,
+				`20:36 ClientUserinfoChanged: 2 n\Test1b\t\0\model\xian/default\hmodel\`,
+				`20:37 Item: 2 item_armor_shard`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:     "game_001",
+				EndReason:    "truncated",
+				StartTime:    "0:00",
+				EndTime:      "20:37",
+				Duration:     1237,
+				MatchConfig:  initGameMatchConfig,
+				Players:      []string{"Test1b"},
+				Kills:        make(map[string]int),
+				KillsByMeans: make(map[string]int),
+				Items: &dto.ItemsReport{
+					ByPlayer: map[string]map[string]int{"Test1b": {"weapon": 1, "armor": 1}},
+					ByClass:  map[string]int{"weapon": 1, "armor": 1},
+					ByItem:   map[string]int{"weapon_rocketlauncher": 1, "item_armor_shard": 1},
+					Powerups: []dto.PowerupPickup{},
+				},
+				NameHistory: map[string][]string{"Test1b": {"Test1", "Test1b"}},
+			},
+		},
+	},
+	{
+		name: "should not mix the stats of two players who used the same slot",
+		args: args{
+			lines: []string{
+				initGameEvent,
+				`20:34 ClientConnect: 2`,
+				userTest1Event,
+				`20:35 Item: 2 weapon_rocketlauncher`,
+				`20:36 ClientDisconnect: 2`,
+				`20:37 ClientConnect: 2`,
+				`20:37 ClientUserinfoChanged: 2 n\Test3\t\0\model\xian/default\hmodel\`,
+				`20:38 Item: 2 item_armor_shard`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:     "game_001",
+				EndReason:    "truncated",
+				StartTime:    "0:00",
+				EndTime:      "20:38",
+				Duration:     1238,
+				MatchConfig:  initGameMatchConfig,
+				Players:      []string{"Test1", "Test3"},
+				Kills:        make(map[string]int),
+				KillsByMeans: make(map[string]int),
+				Items: &dto.ItemsReport{
+					ByPlayer: map[string]map[string]int{"Test1": {"weapon": 1}, "Test3": {"armor": 1}},
+					ByClass:  map[string]int{"weapon": 1, "armor": 1},
+					ByItem:   map[string]int{"weapon_rocketlauncher": 1, "item_armor_shard": 1},
+					Powerups: []dto.PowerupPickup{},
+				},
+			},
+		},
+	},
+	{
+		name: "should keep the stats of a player who reconnects into another slot",
+		args: args{
+			lines: []string{
+				initGameEvent,
+				`20:34 ClientConnect: 2`,
+				userTest1Event,
+				`20:35 Item: 2 weapon_rocketlauncher`,
+				`20:36 ClientDisconnect: 2`,
+				`20:37 ClientConnect: 4`,
+				`20:37 ClientUserinfoChanged: 4 n\Test1\t\0\model\xian/default\hmodel\`,
+				`20:38 Item: 4 item_armor_shard`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:     "game_001",
+				EndReason:    "truncated",
+				StartTime:    "0:00",
+				EndTime:      "20:38",
+				Duration:     1238,
+				MatchConfig:  initGameMatchConfig,
+				Players:      []string{"Test1"},
+				Kills:        make(map[string]int),
+				KillsByMeans: make(map[string]int),
+				Items: &dto.ItemsReport{
+					ByPlayer: map[string]map[string]int{"Test1": {"weapon": 1, "armor": 1}},
+					ByClass:  map[string]int{"weapon": 1, "armor": 1},
+					ByItem:   map[string]int{"weapon_rocketlauncher": 1, "item_armor_shard": 1},
+					Powerups: []dto.PowerupPickup{},
+				},
+			},
+		},
+	},
+	{
+		name: "should not mix the stats of two players in the game with the same name",
+		args: args{
+			lines: []string{
+				initGameEvent,
+				`20:34 ClientUserinfoChanged: 2 n\UnnamedPlayer\t\0\model\xian/default\hmodel\`,
+				`20:34 ClientUserinfoChanged: 3 n\UnnamedPlayer\t\0\model\sarge/default\hmodel\`,
+				`20:35 Item: 2 weapon_rocketlauncher`,
+				`20:36 ClientUserinfoChanged: 2 n\Bob\t\0\model\xian/default\hmodel\`,
+				`20:37 Item: 3 item_armor_shard`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:     "game_001",
+				EndReason:    "truncated",
+				StartTime:    "0:00",
+				EndTime:      "20:37",
+				Duration:     1237,
+				MatchConfig:  initGameMatchConfig,
+				Players:      []string{"Bob", "UnnamedPlayer"},
+				Kills:        make(map[string]int),
+				KillsByMeans: make(map[string]int),
+				Items: &dto.ItemsReport{
+					ByPlayer: map[string]map[string]int{"Bob": {"weapon": 1}, "UnnamedPlayer": {"armor": 1}},
+					ByClass:  map[string]int{"weapon": 1, "armor": 1},
+					ByItem:   map[string]int{"weapon_rocketlauncher": 1, "item_armor_shard": 1},
+					Powerups: []dto.PowerupPickup{},
+				},
+				NameHistory: map[string][]string{"Bob": {"UnnamedPlayer", "Bob"}},
+			},
+		},
+	},
+	{
+		name: "should report the players by their canonical name when they use an alias",
+		cfg:  &config.Config{Aliases: map[string]string{"Test1": "Test1", "T1": "Test1"}},
+		args: args{
+			lines: []string{
+				initGameEvent,
+				`20:34 ClientUserinfoChanged: 2 n\T1\t\0\model\xian/default\hmodel\`,
+				userTest2Event,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:     "game_001",
+				EndReason:    "truncated",
+				StartTime:    "0:00",
+				EndTime:      "20:34",
+				Duration:     1234,
+				MatchConfig:  initGameMatchConfig,
+				Players:      []string{"Test1", "Test2"},
+				Kills:        make(map[string]int),
+				KillsByMeans: make(map[string]int),
+				NameHistory:  map[string][]string{"Test1": {"T1"}},
+			},
+		},
+	},
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"github.com/diegoclair/log-parser/application/dto"
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
+// processItemEvent is a function that processes the item event and updates the item pickups of the gameData.
+func processItemEvent(event entity.ItemEvent, gameData *dto.QuakeData) {
+	class := event.Class()
+
+	if gameData.ItemsByPlayer[event.ClientID] == nil {
+		gameData.ItemsByPlayer[event.ClientID] = make(map[entity.ItemClass]int)
+	}
+	gameData.ItemsByPlayer[event.ClientID][class]++
+	gameData.ItemsByName[event.Item]++
+
+	switch class {
+	case entity.ItemClassPowerup:
+		gameData.Powerups = append(gameData.Powerups, event)
+	case entity.ItemClassFlag:
+		if flagTeam, ok := entity.FlagTeam(event.Item); ok {
+			processFlagItemEvent(event.ClientID, flagTeam, gameData)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package service_test
+
+import "github.com/diegoclair/log-parser/application/dto"
+
+var processItemEventTests = []test{
+	{
+		name: "should count the item pickups by player, class and item and keep the powerup timing",
+		args: args{
+			lines: []string{
+				initGameEvent,
+				userTest1Event,
+				userTest2Event,
+				`20:40 Item: 2 weapon_rocketlauncher`,
+				`20:40 Item: 2 ammo_rockets`,
+				`20:42 Item: 3 item_armor_body`,
+				`20:45 Item: 3 item_quad`,
+				`20:50 Item: 3 weapon_rocketlauncher`,
+				`21:01 Item: 2 item_health_large`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:     "game_001",
+				EndReason:    "truncated",
+				StartTime:    "0:00",
+				EndTime:      "21:01",
+				Duration:     1261,
+				MatchConfig:  initGameMatchConfig,
+				Players:      []string{"Test1", "Test2"},
+				Kills:        make(map[string]int),
+				KillsByMeans: make(map[string]int),
+				Items: &dto.ItemsReport{
+					ByPlayer: map[string]map[string]int{
+						"Test1": {"weapon": 1, "ammo": 1, "health": 1},
+						"Test2": {"armor": 1, "powerup": 1, "weapon": 1},
+					},
+					ByClass: map[string]int{"weapon": 2, "ammo": 1, "armor": 1, "powerup": 1, "health": 1},
+					ByItem: map[string]int{
+						"weapon_rocketlauncher": 2,
+						"ammo_rockets":          1,
+						"item_armor_body":       1,
+						"item_quad":             1,
+						"item_health_large":     1,
+					},
+					Powerups: []dto.PowerupPickup{
+						{Player: "Test2", Item: "item_quad", Time: "20:45", Elapsed: 1245},
+					},
+				},
+			},
+		},
+	},
+}
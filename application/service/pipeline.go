@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sync"
+	"sync/atomic"
+
+	"github.com/diegoclair/log-parser/application/dto"
+)
+
+// gameResult is the result of the extraction of a game segment.
+type gameResult struct {
+	game        int                   // game is the number of the game of the segment.
+	reports     []dto.Report          // reports are the reports of the game of the segment, none for the lines before the first game.
+	diagnostics dto.DiagnosticsReport // diagnostics of the lines of the segment.
+	err         error                 // err is the error of the malformed line that stopped the extraction in strict mode.
+}
+
+// StartExtractingGames is a method that extracts the game segments received from the segmentsChan channel with a pool of workers,
+// one game per worker at a time, and writes the reports to the writerChan channel in the order of the games.
+// The reports and the diagnostics are the same of StartExtractingData for the lines of the segments. In strict mode, the first
+// malformed line in log order stops the extraction, and the games after it are discarded even if they were already extracted.
+// The games extracted ahead of the next one to be written are limited to twice the number of workers, so a long game does not
+// pile up the games after it in memory. As soon as a worker finds the malformed line of a game in strict mode, no more segments
+// are read and the workers stop extracting the games after it, while the games before it are finished to find an earlier one.
+func (s *quakeService) StartExtractingGames(ctx context.Context, segmentsChan <-chan dto.GameSegment, writerChan chan<- dto.Report) error {
+	defer close(writerChan)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	workers := max(s.svc.cfg.Workers, 1)
+	window := make(chan struct{}, 2*workers)
+	jobs := make(chan dto.GameSegment)
+	results := make(chan gameResult)
+
+	// failedGame is the first game with a malformed line found by the workers in strict mode, the games after it are not extracted
+	failedGame := atomic.Int64{}
+	failedGame.Store(math.MaxInt64)
+	dispatchCtx, stopDispatch := context.WithCancel(ctx)
+	defer stopDispatch()
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(jobs)
+		s.dispatchSegments(dispatchCtx, segmentsChan, window, jobs)
+	}()
+
+	workersWg := sync.WaitGroup{}
+	for range workers {
+		workersWg.Add(1)
+		go func() {
+			defer workersWg.Done()
+			for segment := range jobs {
+				stopped := func() bool { return int64(segment.Game) > failedGame.Load() }
+				if stopped() {
+					continue
+				}
+
+				result := s.extractGame(ctx, segment, stopped)
+				if result.err != nil {
+					// the segments are dispatched in log order, so the games before the failed one are already in the workers
+					for failed := failedGame.Load(); int64(segment.Game) < failed; failed = failedGame.Load() {
+						if failedGame.CompareAndSwap(failed, int64(segment.Game)) {
+							break
+						}
+					}
+					stopDispatch()
+				}
+
+				select {
+				case results <- result:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		workersWg.Wait()
+		close(results)
+	}()
+
+	// on return the workers are stopped and the results in flight are dropped, before the writerChan is closed
+	defer func() {
+		cancel()
+		for range results {
+		}
+		wg.Wait()
+	}()
+
+	s.diagnostics = dto.NewDiagnosticsReport()
+
+	// the results arrive in any order, they are held until all the games before them are written
+	pending := make(map[int]gameResult)
+	next := 0
+	for result := range results {
+		pending[result.game] = result
+
+		for {
+			result, ok := pending[next]
+			if !ok {
+				break
+			}
+
+			delete(pending, next)
+			next++
+			<-window
+
+			s.diagnostics.Merge(result.diagnostics)
+			for _, report := range result.reports {
+				writerChan <- report
+			}
+
+			if result.err != nil {
+				return result.err
+			}
+		}
+	}
+
+	return nil
+}
+
+// dispatchSegments sends the segments to the workers, waiting for a free place on the window before reading each one.
+func (s *quakeService) dispatchSegments(ctx context.Context, segmentsChan <-chan dto.GameSegment, window chan<- struct{}, jobs chan<- dto.GameSegment) {
+	for {
+		select {
+		case window <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+
+		var segment dto.GameSegment
+		var ok bool
+		select {
+		case segment, ok = <-segmentsChan:
+			if !ok {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+
+		select {
+		case jobs <- segment:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// extractGame extracts the lines of a game segment, until the end of the segment or until stopped returns true.
+// The game in progress at the end of the segment is reported, as the next segment starts a new game,
+// unless it is the last segment of a log file, where a game without players is not reported.
+func (s *quakeService) extractGame(ctx context.Context, segment dto.GameSegment, stopped func() bool) gameResult {
+	result := gameResult{game: segment.Game}
+
+	e := newExtraction(s.svc, max(segment.Game-1, 0), func(report dto.Report) {
+		result.reports = append(result.reports, report)
+	})
+
+	for _, line := range segment.Lines {
+		if stopped() {
+			return result
+		}
+
+		if err := e.processLine(ctx, line); err != nil {
+			result.err = err
+			result.diagnostics = e.diagnostics
+			return result
+		}
+	}
+
+	if segment.Last {
+		e.sendLastGameReport()
+	} else if e.currentGame > 0 {
+		e.sendGameReport()
+	}
+
+	result.diagnostics = e.diagnostics
+
+	return result
+}
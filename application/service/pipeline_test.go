@@ -0,0 +1,320 @@
+package service_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"sync/atomic"
+	"testing"
+
+	"github.com/diegoclair/go_utils/logger"
+	"github.com/diegoclair/log-parser/application/contract"
+	"github.com/diegoclair/log-parser/application/dto"
+	"github.com/diegoclair/log-parser/application/service"
+	"github.com/diegoclair/log-parser/infra/config"
+	"github.com/stretchr/testify/require"
+)
+
+// extractGames sends the lines to a new service as game segments and returns the service, the reports and the error of the extraction.
+// The segments are sent from a goroutine that stops when the service stops reading them.
+func extractGames(t testing.TB, cfg *config.Config, lines []dto.LogLine) (contract.QuakeService, []dto.Report, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	segmentsChan := make(chan dto.GameSegment)
+	go func() {
+		defer close(segmentsChan)
+		for _, segment := range gameSegments(lines) {
+			select {
+			case segmentsChan <- segment:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	writerChan := make(chan dto.Report)
+	errChan := make(chan error, 1)
+	svc := getQuakeService(t, cfg)
+	go func() {
+		errChan <- svc.StartExtractingGames(ctx, segmentsChan, writerChan)
+	}()
+
+	reports := []dto.Report{}
+	for report := range writerChan {
+		sort.Strings(report.Players)
+		reports = append(reports, report)
+	}
+
+	return svc, reports, <-errChan
+}
+
+// gameSegments splits the lines at the InitGame lines and at the first line of each log file, like the reader of the logs.
+func gameSegments(lines []dto.LogLine) []dto.GameSegment {
+	segments := []dto.GameSegment{{Game: 0}}
+	inGame := false
+	for _, line := range lines {
+		if line.Number == 1 && inGame {
+			segments[len(segments)-1].Last = true
+			segments = append(segments, dto.GameSegment{Game: len(segments)})
+			inGame = false
+		}
+
+		if line.IsInitGame() {
+			if inGame || len(segments) == 1 {
+				segments = append(segments, dto.GameSegment{Game: len(segments)})
+			}
+			inGame = true
+		}
+
+		last := &segments[len(segments)-1]
+		last.Lines = append(last.Lines, line)
+	}
+
+	if last := segments[len(segments)-1]; last.Game > 0 && len(last.Lines) == 0 {
+		segments = segments[:len(segments)-1]
+	}
+	segments[len(segments)-1].Last = true
+
+	return segments
+}
+
+// sortedReports returns the reports of the extraction of the lines, one line at a time, with the players sorted.
+func sortedReports(t testing.TB, cfg *config.Config, lines []dto.LogLine) (contract.QuakeService, []dto.Report, error) {
+	svc, reports, err := extractData(t, cfg, lines)
+	for i := range reports {
+		sort.Strings(reports[i].Players)
+	}
+
+	return svc, reports, err
+}
+
+func TestQuakeService_StartExtractingGames(t *testing.T) {
+	lines := logLines(
+		`  0:00 Warmup: 1`,
+		initGameEvent,
+		userTest1Event,
+		`20:40 Kill: 1022 2 22: <world> killed Test1 by MOD_TRIGGER_HURT`,
+		`20:41 Exit: Fraglimit hit.`,
+		initGameEvent,
+		userTest1Event,
+		` 26`,
+		`  0:00 ------------------------------------------------------------`,
+		initGameEvent,
+		userTest2Event,
+		`20:30 Item: 3 weapon_rocketlauncher`,
+		`20:20 Item: 3 weapon_shotgun`,
+		initGameEvent,
+	)
+	// the separator is glued to the partial record on the line 8, like the reader sends them
+	lines[7].Partial = true
+	lines[8].Number, lines[8].Offset, lines[8].Glued = 8, lines[7].Offset+3, true
+
+	wantSvc, want, err := sortedReports(t, config.GetDefaultConfig(), lines)
+	require.NoError(t, err)
+	require.Len(t, want, 3)
+	require.Equal(t, 13, wantSvc.Diagnostics().Lines)
+
+	for _, workers := range []int{1, 3} {
+		t.Run(fmt.Sprintf("should be the same of the extraction line by line with %d workers", workers), func(t *testing.T) {
+			cfg := config.GetDefaultConfig()
+			cfg.Workers = workers
+
+			svc, reports, err := extractGames(t, cfg, lines)
+			require.NoError(t, err)
+			require.Equal(t, want, reports)
+			require.Equal(t, wantSvc.Diagnostics(), svc.Diagnostics())
+		})
+	}
+}
+
+func TestQuakeService_StartExtractingGames_Files(t *testing.T) {
+	lines := slices.Concat(
+		logLines(
+			initGameEvent,
+			userTest1Event,
+			`20:40 Kill: 1022 2 22: <world> killed Test1 by MOD_TRIGGER_HURT`,
+		),
+		// the kill before the first game of the second file is outside of any game
+		logLines(
+			`20:41 Kill: 1022 2 22: <world> killed Test1 by MOD_TRIGGER_HURT`,
+			initGameEvent,
+			userTest2Event,
+			`20:50 Exit: Fraglimit hit.`,
+		),
+	)
+
+	wantSvc, want, err := sortedReports(t, config.GetDefaultConfig(), lines)
+	require.NoError(t, err)
+	require.Len(t, want, 2)
+	require.Equal(t, "truncated", want[0].EndReason)
+	require.Equal(t, 1, want[0].TotalKills)
+	require.Equal(t, "20:40", want[0].EndTime)
+	require.Equal(t, "fraglimit", want[1].EndReason)
+	require.Equal(t, []string{"Test2"}, want[1].Players)
+	require.Equal(t, 0, want[1].TotalKills)
+
+	for _, workers := range []int{1, 3} {
+		t.Run(fmt.Sprintf("should end the game at the end of each file like the extraction line by line with %d workers", workers), func(t *testing.T) {
+			cfg := config.GetDefaultConfig()
+			cfg.Workers = workers
+
+			svc, reports, err := extractGames(t, cfg, lines)
+			require.NoError(t, err)
+			require.Equal(t, want, reports)
+			require.Equal(t, wantSvc.Diagnostics(), svc.Diagnostics())
+		})
+	}
+}
+
+func TestQuakeService_StartExtractingGames_Order(t *testing.T) {
+	lines := []string{}
+	for i := 0; i < 20; i++ {
+		lines = append(lines, initGameEvent, userTest1Event)
+		// the first games are the longest, so they finish after the games behind them
+		for j := 0; j < 20-i; j++ {
+			lines = append(lines, `20:40 Kill: 1022 2 22: <world> killed Test1 by MOD_TRIGGER_HURT`)
+		}
+	}
+
+	cfg := config.GetDefaultConfig()
+	cfg.Workers = 4
+
+	_, reports, err := extractGames(t, cfg, logLines(lines...))
+	require.NoError(t, err)
+	require.Len(t, reports, 20)
+	for i, report := range reports {
+		require.Equal(t, fmt.Sprintf("game_%03d", i+1), report.GameName)
+		require.Equal(t, 20-i, report.TotalKills)
+	}
+}
+
+func TestQuakeService_StartExtractingGames_Strict(t *testing.T) {
+	lines := logLines(
+		initGameEvent,
+		userTest1Event,
+		initGameEvent,
+		userTest1Event,
+		` 26  0:00 ------------------------------------------------------------`,
+		initGameEvent,
+		`20:40 Kill: a`,
+	)
+
+	cfg := &config.Config{Strict: true, Workers: 3}
+	svc, reports, err := extractGames(t, cfg, lines)
+
+	// the first malformed line in log order stops the extraction, even if a later game failed first
+	require.ErrorContains(t, err, "malformed line 5")
+	require.Len(t, reports, 1)
+	require.Equal(t, "game_001", reports[0].GameName)
+	require.Equal(t, 1, svc.Diagnostics().Errors)
+	require.Equal(t, 5, svc.Diagnostics().Lines)
+}
+
+// errorCounter is a logger that counts the error messages.
+type errorCounter struct {
+	logger.Logger
+	errors atomic.Int32
+}
+
+func (l *errorCounter) Error(ctx context.Context, msg string) {
+	l.errors.Add(1)
+}
+
+func TestQuakeService_StartExtractingGames_StrictStopsWorkers(t *testing.T) {
+	lines := []string{initGameEvent, userTest1Event}
+	// the first game is long, so the malformed line of the second game is found while it is still extracted
+	for i := 0; i < 100000; i++ {
+		lines = append(lines, `20:40 Item: 2 weapon_rocketlauncher`)
+	}
+	for i := 0; i < 20; i++ {
+		lines = append(lines, initGameEvent, `20:40 Kill: a`)
+	}
+
+	log := &errorCounter{Logger: logger.NewNoop()}
+	services, err := service.New(log, &config.Config{Strict: true, Workers: 2})
+	require.NoError(t, err)
+
+	segmentsChan := make(chan dto.GameSegment)
+	go func() {
+		defer close(segmentsChan)
+		for _, segment := range gameSegments(logLines(lines...)) {
+			segmentsChan <- segment
+		}
+	}()
+
+	writerChan := make(chan dto.Report)
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- services.QuakeService.StartExtractingGames(context.Background(), segmentsChan, writerChan)
+	}()
+
+	reports := []dto.Report{}
+	for report := range writerChan {
+		reports = append(reports, report)
+	}
+
+	// the games after the failed one are not extracted, so only its malformed line is logged
+	require.ErrorContains(t, <-errChan, "malformed line 100004")
+	require.Len(t, reports, 1)
+	require.Equal(t, int32(1), log.errors.Load())
+}
+
+func TestQuakeService_StartExtractingGames_SampleLog(t *testing.T) {
+	lines := readSampleLog(t)
+
+	cfg := config.GetDefaultConfig()
+	cfg.Workers = 4
+
+	wantSvc, want, err := sortedReports(t, cfg, lines)
+	require.NoError(t, err)
+
+	svc, reports, err := extractGames(t, cfg, lines)
+	require.NoError(t, err)
+	require.Equal(t, want, reports)
+	require.Equal(t, wantSvc.Diagnostics(), svc.Diagnostics())
+}
+
+// BenchmarkQuakeService_StartExtractingGames compares the extraction line by line with the extraction of the games by the workers.
+// Run it with: go test -bench StartExtractingGames -benchmem ./application/service
+func BenchmarkQuakeService_StartExtractingGames(b *testing.B) {
+	lines := readSampleLog(b)
+
+	b.Run("lines", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _, _ = extractData(b, config.GetDefaultConfig(), lines)
+		}
+	})
+
+	for _, workers := range []int{1, 4} {
+		b.Run(fmt.Sprintf("games/workers=%d", workers), func(b *testing.B) {
+			cfg := config.GetDefaultConfig()
+			cfg.Workers = workers
+			for i := 0; i < b.N; i++ {
+				_, _, _ = extractGames(b, cfg, lines)
+			}
+		})
+	}
+}
+
+// readSampleLog returns the lines of the sample log in the root of the repository.
+func readSampleLog(tb testing.TB) []dto.LogLine {
+	tb.Helper()
+
+	file, err := os.Open("../../qgames.log")
+	if err != nil {
+		tb.Skipf("sample log not found: %v", err)
+	}
+	defer file.Close()
+
+	lines := []dto.LogLine{}
+	scanner := bufio.NewScanner(file)
+	for number := 1; scanner.Scan(); number++ {
+		lines = append(lines, dto.LogLine{Text: scanner.Text(), Number: number})
+	}
+
+	return lines
+}
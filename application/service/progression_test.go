@@ -0,0 +1,63 @@
+package service_test
+
+import (
+	"time"
+
+	"github.com/diegoclair/log-parser/application/dto"
+	"github.com/diegoclair/log-parser/infra/config"
+)
+
+var processScoreProgressionTests = []test{
+	{
+		name: "should report the score progression per kill and per interval when it is enabled",
+		cfg:  &config.Config{ScoreInterval: 15 * time.Second},
+		args: args{
+			lines: []string{
+				`20:30 InitGame: \sv_floodProtect\1\sv_maxPing\0\sv_minPing\0\`,
+				userTest1Event,
+				userTest2Event,
+				`20:40 Kill: 3 2 7: Test2 killed Test1 by MOD_ROCKET_SPLASH`,
+				`20:50 Kill: 1022 3 22: <world> killed Test2 by MOD_TRIGGER_HURT`,
+				`21:00 Kill: 2 2 7: Test1 killed Test1 by MOD_ROCKET_SPLASH`,
+				`21:10 Exit: Fraglimit hit.`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:     "game_001",
+				EndReason:    "fraglimit",
+				Complete:     true,
+				StartTime:    "20:30",
+				EndTime:      "21:10",
+				Duration:     40,
+				MatchConfig:  initGameMatchConfig,
+				TotalKills:   3,
+				Players:      []string{"Test1", "Test2"},
+				Kills:        map[string]int{"Test2": 0},
+				KillsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 2, "MOD_TRIGGER_HURT": 1},
+				PlayerStats: map[string]dto.PlayerStats{
+					"Test1": {Deaths: 2, Suicides: 1, NetScore: -1, LongestLife: 20, KillsByMeans: map[string]int{}, DeathsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 2}},
+					"Test2": {Frags: 1, Deaths: 1, WorldDeaths: 1, KDRatio: 1, LongestStreak: 1, LongestLife: 20, KillsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 1}, DeathsByMeans: map[string]int{"MOD_TRIGGER_HURT": 1}},
+				},
+				HeadToHead: dto.HeadToHead{
+					"Test2": {"Test1": 1},
+				},
+				FirstBlood: &dto.FirstBlood{Killer: "Test2", Victim: "Test1", Time: "20:40", Elapsed: 10},
+				ScoreProgression: &dto.ScoreProgression{
+					Interval: 15,
+					// the suicide does not change the kills score, so it has no sample
+					Events: []dto.ScoreSample{
+						{Time: "20:40", Elapsed: 10, Scores: map[string]int{"Test1": 0, "Test2": 1}, Leaders: []string{"Test2"}},
+						{Time: "20:50", Elapsed: 20, Scores: map[string]int{"Test1": 0, "Test2": 0}, Leaders: []string{"Test1", "Test2"}},
+					},
+					Intervals: []dto.ScoreSample{
+						{Time: "20:30", Elapsed: 0, Scores: map[string]int{"Test1": 0, "Test2": 0}, Leaders: []string{"Test1", "Test2"}},
+						{Time: "20:45", Elapsed: 15, Scores: map[string]int{"Test1": 0, "Test2": 1}, Leaders: []string{"Test2"}},
+						{Time: "21:00", Elapsed: 30, Scores: map[string]int{"Test1": 0, "Test2": 0}, Leaders: []string{"Test1", "Test2"}},
+						{Time: "21:10", Elapsed: 40, Scores: map[string]int{"Test1": 0, "Test2": 0}, Leaders: []string{"Test1", "Test2"}},
+					},
+				},
+			},
+		},
+	},
+}
@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/diegoclair/log-parser/application"
 	"github.com/diegoclair/log-parser/application/contract"
@@ -12,118 +14,309 @@ import (
 
 // quakeService is a struct that implements the contract.QuakeService interface.
 type quakeService struct {
-	svc *service
+	svc         *service
+	diagnostics dto.DiagnosticsReport
 }
 
 // newQuakeService creates a new instance of the quakeService struct.
 func newQuakeService(svc *service) contract.QuakeService {
 	return &quakeService{
-		svc: svc,
+		svc:         svc,
+		diagnostics: dto.NewDiagnosticsReport(),
 	}
 }
 
 // StartExtractingData is a method that starts extracting data from the rawLinesChan channel and writes the report to the writerChan channel.
-func (s *quakeService) StartExtractingData(ctx context.Context, rawLinesChan <-chan string, writerChan chan<- dto.Report) {
+// The unparseable and suspicious lines are collected on the diagnostics. In strict mode, the first malformed line stops the extraction
+// and its error is returned, without the report of the game of the line.
+// A partial line, cut by a crash of the server, ends the game in progress as truncated, and the lines until the next game are skipped.
+// The first line of a log file, numbered 1, ends the game in progress too, like the end of the segments of a file on StartExtractingGames.
+// It is the sequential reference of StartExtractingGames, which the application uses.
+func (s *quakeService) StartExtractingData(ctx context.Context, rawLinesChan <-chan dto.LogLine, writerChan chan<- dto.Report) error {
 	defer close(writerChan)
 
-	var gameData dto.QuakeData
-	gameCount := 0
+	e := newExtraction(s.svc, 0, func(report dto.Report) { writerChan <- report })
+	defer func() { s.diagnostics = e.diagnostics }()
 
 	for line := range rawLinesChan {
-		processed := processNewGameEvent(line, gameCount, &gameData, writerChan)
-		if processed {
-			gameCount++
-			continue
+		// the line numbers start again on each log file, and a game does not continue on the next file
+		if line.Number == 1 && e.currentGame > 0 {
+			e.sendLastGameReport()
+			e.gameData.Reset()
+			e.currentGame = 0
 		}
 
-		// if gameCount still 0 here, then we don't have a game yet
-		if gameCount == 0 {
-			continue
+		if err := e.processLine(ctx, line); err != nil {
+			return err
 		}
+	}
+
+	e.sendLastGameReport()
+
+	return nil
+}
+
+// Diagnostics is a method that returns the unparseable and suspicious lines of the last extraction.
+func (s *quakeService) Diagnostics() dto.DiagnosticsReport {
+	return s.diagnostics
+}
+
+// extraction holds the state of the extraction of a sequence of lines, a whole log or the segment of one game.
+type extraction struct {
+	svc         *service
+	gameData    dto.QuakeData
+	gameCount   int // gameCount is the number of the last game started.
+	currentGame int // currentGame is the number of the game in progress, 0 if there is none.
+	diagnostics dto.DiagnosticsReport
+	send        func(report dto.Report) // send is called with the report of each game, in log order.
+}
+
+// newExtraction creates an extraction whose first game is the one after gameCount.
+func newExtraction(svc *service, gameCount int, send func(report dto.Report)) *extraction {
+	return &extraction{
+		svc:         svc,
+		gameCount:   gameCount,
+		diagnostics: dto.NewDiagnosticsReport(),
+		send:        send,
+	}
+}
+
+// processLine parses the line and updates the game in progress with its event.
+// It only returns an error in strict mode, for a malformed line.
+func (e *extraction) processLine(ctx context.Context, line dto.LogLine) error {
+	// the records glued to a partial record are on the line of the partial record, which was already counted
+	if !line.Glued {
+		e.diagnostics.Lines++
+	}
+
+	if line.Partial {
+		e.diagnose(line, dto.DiagnosticWarning, dto.DiagnosticPartialLine, errors.New("record cut by a crash of the server"))
+		if e.currentGame > 0 {
+			e.sendGameReport()
+			e.gameData.Reset()
+			e.currentGame = 0
+		}
+		return nil
+	}
 
-		processed, err := s.processUserChangedEvent(ctx, line, &gameData)
-		if err != nil || processed {
-			continue
+	// the empty lines, like the ones at the end of a log, are not records
+	if strings.TrimSpace(line.Text) == "" {
+		return nil
+	}
+
+	event, err := line.Event()
+	if errors.Is(err, entity.ErrUnknownEvent) {
+		e.diagnose(line, dto.DiagnosticWarning, dto.DiagnosticUnknownEvent, err)
+		return nil
+	}
+	if err != nil {
+		e.svc.log.Error(ctx, fmt.Sprintf("Error to parse line: %v", err))
+		e.diagnose(line, dto.DiagnosticError, dto.DiagnosticMalformedLine, err)
+		if e.svc.cfg.Strict {
+			return fmt.Errorf("malformed line %d: %w", line.Number, err)
 		}
+		return nil
+	}
+
+	if initGame, ok := event.(entity.InitGameEvent); ok {
+		e.processNewGameEvent(initGame)
+		return nil
+	}
+
+	// if currentGame is 0 here, then we don't have a game yet, or it was cut by a crash
+	if e.currentGame == 0 {
+		return nil
+	}
+
+	gameData := &e.gameData
+
+	// the separators are printed with the clock of the next game, so they are not diagnosed and do not end the game
+	_, separator := event.(entity.SeparatorEvent)
+	if !separator && event.Timestamp().Duration() < gameData.EndTime.Duration() {
+		err := fmt.Errorf("timestamp %s is before %s", event.Timestamp(), gameData.EndTime)
+		e.diagnose(line, dto.DiagnosticWarning, dto.DiagnosticClockBackwards, err)
+	}
 
-		// should use return values to do continue if this function is not the last in the chain
-		_, _ = s.processKillEvent(ctx, line, &gameData)
+	// a clock before the start of the game comes from a restarted server, it would give the game a negative duration
+	if !separator && event.Timestamp().Duration() >= gameData.StartTime.Duration() {
+		gameData.EndTime = event.Timestamp()
 	}
+	event = resolvePlayerIDs(event, gameData, e.svc.cfg.Aliases)
 
-	s.sendLastGameReport(&gameData, gameCount, writerChan)
+	// the timeline is recorded before the event changes the game data, to compare the teams before and after
+	if e.svc.cfg.Timeline {
+		recordTimelineEvent(event, gameData)
+	}
+
+	switch ev := event.(type) {
+	case entity.ClientUserinfoChangedEvent:
+		processUserChangedEvent(ev, gameData)
+	case entity.KillEvent:
+		// the kill is still counted, the name of the mean is the one reported
+		if err := entity.ValidateMean(ev.MeanID, ev.DeathCause); err != nil {
+			e.diagnose(line, dto.DiagnosticWarning, dto.DiagnosticInvalidMean, err)
+		}
+		processKillEvent(ev, gameData)
+		processKillStreakEvent(ev, gameData, e.svc.cfg.MultiKillWindow)
+		if e.svc.cfg.ScoreInterval > 0 {
+			gameData.RecordScores(ev)
+		}
+	case entity.ExitEvent:
+		processExitEvent(ev, gameData)
+	case entity.ShutdownGameEvent:
+		processShutdownGameEvent(gameData)
+	case entity.ScoreEvent:
+		processScoreEvent(ev, gameData)
+	case entity.TeamScoreEvent:
+		processTeamScoreEvent(ev, gameData)
+	case entity.ItemEvent:
+		processItemEvent(ev, gameData)
+	case entity.CTFEvent:
+		processCTFEvent(ev, gameData)
+	case entity.ClientBeginEvent:
+		processClientBeginEvent(ev, gameData)
+	case entity.ClientDisconnectEvent:
+		processClientDisconnectEvent(ev, gameData)
+	case entity.SayEvent:
+		processSayEvent(ev, gameData, e.svc.cfg.WordFilter)
+	}
+
+	return nil
+}
+
+// diagnose records the problem of the line on the diagnostics, with the game of the line if there is one.
+func (e *extraction) diagnose(line dto.LogLine, severity dto.DiagnosticSeverity, reason dto.DiagnosticReason, err error) {
+	diagnostic := dto.Diagnostic{
+		Source:   line.Source,
+		Line:     line.Number,
+		Offset:   line.Offset,
+		Severity: severity,
+		Reason:   reason,
+		Detail:   err.Error(),
+		Text:     line.Text,
+	}
+
+	if e.currentGame > 0 {
+		diagnostic.Game = generateGameName(e.currentGame)
+	}
+
+	e.diagnostics.Add(diagnostic)
 }
 
-func (s *quakeService) sendLastGameReport(gameData *dto.QuakeData, gameCount int, writerChan chan<- dto.Report) {
+// sendLastGameReport sends the report of the game in progress at the end of the log, or of a log file.
+func (e *extraction) sendLastGameReport() {
 	// if there are no players, we don't consider it a game
-	if len(gameData.Players) == 0 {
+	if e.currentGame == 0 || len(e.gameData.Players) == 0 {
 		return
 	}
 
-	writerChan <- gameData.ToReport(generateGameName(gameCount))
+	e.sendGameReport()
 }
 
-// processNewGameEvent is a method that processes the new game event from the given line and updates the gameData.
-// It also writes the gameData to the writerChan channel if the gameCount is greater than 0.
-// It returns true if the line is a new game event.
-func processNewGameEvent(line string, gameCount int, gameData *dto.QuakeData, writerChan chan<- dto.Report) (processed bool) {
-	if entity.IsNewGameByLine(line) {
-		if gameCount > 0 {
-			writerChan <- gameData.ToReport(generateGameName(gameCount))
-		}
+// sendGameReport sends the report of the game in progress.
+// A game without an end reason at this point never ended, so it is marked as truncated.
+func (e *extraction) sendGameReport() {
+	gameData := &e.gameData
+	if gameData.EndReason == "" {
+		gameData.EndReason = entity.EndReasonTruncated
+	}
 
-		// reset game data for the new game stats
-		gameData.Reset()
-		return true
+	reconcileScoreboard(gameData)
+
+	cfg := e.svc.cfg
+	report := gameData.ToReport(generateGameName(e.currentGame))
+	if cfg.GroupByWeapon {
+		report.GroupByWeapon()
+	}
+	report.Awards = report.EvaluateAwards(cfg.Awards)
+	if !cfg.Chat {
+		report.Chat = nil
+	}
+	if cfg.ScoreInterval > 0 {
+		report.ScoreProgression = gameData.ToScoreProgression(cfg.ScoreInterval)
 	}
 
-	return false
+	e.send(report)
 }
 
-// processUserChangedEvent is a method that processes the user changed event from the given line and updates the gameData.
-// It returns true if the line is a user changed event.
-func (s *quakeService) processUserChangedEvent(ctx context.Context, line string, gameData *dto.QuakeData) (processed bool, err error) {
-	hasPlayer, player, err := entity.IsUserInfoChangedByLine(line)
-	if err != nil {
-		s.svc.log.Error(ctx, fmt.Sprintf("Error to process user changed event: %v", err))
-		return false, err
+// processNewGameEvent processes the new game event, sending the report of the game in progress if there is one,
+// and resets the gameData with the new match config.
+func (e *extraction) processNewGameEvent(event entity.InitGameEvent) {
+	if e.currentGame > 0 {
+		e.sendGameReport()
 	}
 
-	if hasPlayer {
-		gameData.Players[player.ID] = player.Name
-		return true, nil
+	e.gameCount++
+	e.currentGame = e.gameCount
+
+	// reset game data for the new game stats
+	e.gameData.Reset()
+	e.gameData.Config = event.Config
+	e.gameData.StartTime = event.Time
+	e.gameData.EndTime = event.Time
+}
+
+// processExitEvent is a function that processes the exit event and sets the end reason of the game.
+func processExitEvent(event entity.ExitEvent, gameData *dto.QuakeData) {
+	gameData.EndReason = event.EndReason()
+}
+
+// processShutdownGameEvent is a function that processes the shutdown event.
+// The end reason is only set if the game was not exited before, because the server always shuts down after an exit.
+func processShutdownGameEvent(gameData *dto.QuakeData) {
+	if gameData.EndReason == "" {
+		gameData.EndReason = entity.EndReasonShutdown
 	}
+}
 
-	return false, nil
+// processUserChangedEvent is a function that processes the user changed event and updates the gameData.
+func processUserChangedEvent(event entity.ClientUserinfoChangedEvent, gameData *dto.QuakeData) {
+	gameData.Players[event.ClientID] = event.Name
+	gameData.Teams[event.ClientID] = event.Team
 }
 
-// processKillEvent is a method that processes the kill event from the given line and updates the gameData.
-// It returns true if the line is a kill event.
-func (s *quakeService) processKillEvent(ctx context.Context, line string, gameData *dto.QuakeData) (processed bool, err error) {
-	hasKill, killInfo, err := entity.IsKillByLine(line)
-	if err != nil {
-		s.svc.log.Error(ctx, fmt.Sprintf("Error to process kill event: %v", err))
-		return false, err
+// processKillEvent is a function that processes the kill event and updates the gameData.
+func processKillEvent(event entity.KillEvent, gameData *dto.QuakeData) {
+	gameData.TotalKills++
+	gameData.KillsByMeans[event.DeathCause]++
+
+	processFlagCarrierKill(event, gameData)
+
+	// every death is counted for the killed player, including suicides and world kills
+	killedStats := gameData.GetPlayerStats(event.KilledID)
+	killedStats.LongestLife = max(killedStats.LongestLife, killedStats.Life(event.Time, gameData.StartTime))
+	killedStats.LastDeathTime = event.Time
+	killedStats.Deaths++
+	killedStats.DeathsByMeans[event.DeathCause]++
+
+	if event.KillerID == application.WorldPlayerID {
+		gameData.Kills[event.KilledID]--
+		killedStats.WorldDeaths++
+		return
 	}
 
-	if hasKill {
-		gameData.TotalKills++
-		gameData.KillsByMeans[killInfo.DeathCause]++
+	// do not count as kill for an user if the killer is the same as the killed
+	if event.KillerID == event.KilledID {
+		killedStats.Suicides++
+		return
+	}
 
-		if killInfo.KillerID == application.WorldPlayerID {
-			gameData.Kills[killInfo.KilledID]--
-			return true, nil
-		}
+	if gameData.HeadToHead[event.KillerID] == nil {
+		gameData.HeadToHead[event.KillerID] = make(map[int]int)
+	}
+	gameData.HeadToHead[event.KillerID][event.KilledID]++
 
-		// do not count as kill for an user if the killer is the same as the killed
-		if killInfo.KillerID == killInfo.KilledID {
-			return true, nil
-		}
+	gameData.Kills[event.KillerID]++
 
-		gameData.Kills[killInfo.KillerID]++
-		return true, nil
+	// friendly fire is still counted on the kills, but not on the frags of the player stats
+	if isTeamKill(event, gameData) {
+		gameData.TeamKills[event.KillerID]++
+		return
 	}
 
-	return false, nil
+	killerStats := gameData.GetPlayerStats(event.KillerID)
+	killerStats.Frags++
+	killerStats.KillsByMeans[event.DeathCause]++
 }
 
 // generateGameName generates a game name based on the gameCount.
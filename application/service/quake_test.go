@@ -12,12 +12,13 @@ import (
 	"github.com/diegoclair/log-parser/application/contract"
 	"github.com/diegoclair/log-parser/application/dto"
 	"github.com/diegoclair/log-parser/application/service"
+	"github.com/diegoclair/log-parser/infra/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func getQuakeService(t *testing.T) contract.QuakeService {
-	services, err := service.New(logger.NewNoop())
+func getQuakeService(t testing.TB, cfg *config.Config) contract.QuakeService {
+	services, err := service.New(logger.NewNoop(), cfg)
 	assert.NoError(t, err)
 
 	return services.QuakeService
@@ -29,6 +30,7 @@ type args struct {
 
 type test struct {
 	name      string
+	cfg       *config.Config // cfg is the service config of the test, the default config if nil.
 	args      args
 	setupTest func(a args)
 	want      []dto.Report
@@ -42,6 +44,10 @@ var (
 	killEventDifferentName = `22:06 Kill: 3 2 7: Xxxxx1 killed Xxxxx2 by MOD_ROCKET_SPLASH` // name should be found by id 3 and 2
 	worldKillEvent         = `22:06 Kill: 1022 2 19: <world> killed Test1 by MOD_TRIGGER_HURT`
 	samePlayerKillEvent    = `22:06 Kill: 3 3 7: Test2 killed Test2 by MOD_ROCKET_SPLASH`
+
+	initGameMatchConfig = dto.MatchConfig{GameType: "FFA"}
+	emptyTeams          = map[string]dto.TeamReport{"red": {Players: []string{}}, "blue": {Players: []string{}}}
+	emptyCTF            = &dto.CTFReport{Players: map[string]dto.CTFStats{}, Teams: map[string]dto.CTFStats{"red": {}, "blue": {}}}
 )
 
 var sendLastGameReportTests = []test{
@@ -61,6 +67,11 @@ var sendLastGameReportTests = []test{
 		want: []dto.Report{
 			{
 				GameName:     "game_001",
+				EndReason:    "truncated",
+				StartTime:    "0:00",
+				EndTime:      "20:34",
+				Duration:     1234,
+				MatchConfig:  initGameMatchConfig,
 				Players:      []string{"Test1"},
 				Kills:        make(map[string]int),
 				KillsByMeans: make(map[string]int),
@@ -90,6 +101,44 @@ var processNewGameEventTests = []test{
 		want: []dto.Report{
 			{
 				GameName:     "game_001",
+				EndReason:    "truncated",
+				StartTime:    "0:00",
+				EndTime:      "20:34",
+				Duration:     1234,
+				MatchConfig:  initGameMatchConfig,
+				Players:      []string{"Test1"},
+				Kills:        make(map[string]int),
+				KillsByMeans: make(map[string]int),
+			},
+		},
+	},
+	{
+		name: "should parse the match config from the new game event",
+		args: args{
+			lines: []string{
+				`  0:00 InitGame: \sv_hostname\Code Miner Server\g_gametype\4\fraglimit\20\timelimit\15\capturelimit\8\version\ioq3 1.36\protocol\68\mapname\Q3TOURNEY6_CTF\gamename\baseq3`,
+				userTest1Event,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:  "game_001",
+				EndReason: "truncated",
+				StartTime: "0:00",
+				EndTime:   "20:34",
+				Duration:  1234,
+				MatchConfig: dto.MatchConfig{
+					MapName:      "Q3TOURNEY6_CTF",
+					GameType:     "CTF",
+					FragLimit:    20,
+					TimeLimit:    15,
+					CaptureLimit: 8,
+					Hostname:     "Code Miner Server",
+					Version:      "ioq3 1.36",
+					Protocol:     68,
+				},
+				Teams:        emptyTeams,
+				CTF:          emptyCTF,
 				Players:      []string{"Test1"},
 				Kills:        make(map[string]int),
 				KillsByMeans: make(map[string]int),
@@ -109,12 +158,22 @@ var processNewGameEventTests = []test{
 		want: []dto.Report{
 			{
 				GameName:     "game_001",
+				EndReason:    "truncated",
+				StartTime:    "0:00",
+				EndTime:      "20:34",
+				Duration:     1234,
+				MatchConfig:  initGameMatchConfig,
 				Players:      []string{"Test1"},
 				Kills:        make(map[string]int),
 				KillsByMeans: make(map[string]int),
 			},
 			{
 				GameName:     "game_002",
+				EndReason:    "truncated",
+				StartTime:    "0:00",
+				EndTime:      "20:34",
+				Duration:     1234,
+				MatchConfig:  initGameMatchConfig,
 				Players:      []string{"Test2"},
 				Kills:        make(map[string]int),
 				KillsByMeans: make(map[string]int),
@@ -135,6 +194,11 @@ var processUserChangedEventTests = []test{
 		want: []dto.Report{
 			{
 				GameName:     "game_001",
+				EndReason:    "truncated",
+				StartTime:    "0:00",
+				EndTime:      "20:34",
+				Duration:     1234,
+				MatchConfig:  initGameMatchConfig,
 				Players:      []string{"Test1"},
 				Kills:        make(map[string]int),
 				KillsByMeans: make(map[string]int),
@@ -153,6 +217,11 @@ var processUserChangedEventTests = []test{
 		want: []dto.Report{
 			{
 				GameName:     "game_001",
+				EndReason:    "truncated",
+				StartTime:    "0:00",
+				EndTime:      "20:34",
+				Duration:     1234,
+				MatchConfig:  initGameMatchConfig,
 				Players:      []string{"Test1"},
 				Kills:        make(map[string]int),
 				KillsByMeans: make(map[string]int),
@@ -174,15 +243,28 @@ var processKillEventTests = []test{
 		},
 		want: []dto.Report{
 			{
-				GameName:   "game_001",
-				TotalKills: 1,
-				Players:    []string{"Test1", "Test2"},
+				GameName:    "game_001",
+				EndReason:   "truncated",
+				StartTime:   "0:00",
+				EndTime:     "22:06",
+				Duration:    1326,
+				MatchConfig: initGameMatchConfig,
+				TotalKills:  1,
+				Players:     []string{"Test1", "Test2"},
 				Kills: map[string]int{
 					"Test2": 1,
 				},
 				KillsByMeans: map[string]int{
 					"MOD_ROCKET_SPLASH": 1,
 				},
+				PlayerStats: map[string]dto.PlayerStats{
+					"Test1": {Deaths: 1, LongestLife: 1326, KillsByMeans: map[string]int{}, DeathsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 1}},
+					"Test2": {Frags: 1, NetScore: 1, KDRatio: 1, LongestStreak: 1, LongestLife: 1326, KillsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 1}, DeathsByMeans: map[string]int{}},
+				},
+				HeadToHead: dto.HeadToHead{
+					"Test2": {"Test1": 1},
+				},
+				FirstBlood: &dto.FirstBlood{Killer: "Test2", Victim: "Test1", Time: "22:06", Elapsed: 1326},
 			},
 		},
 	},
@@ -199,15 +281,28 @@ var processKillEventTests = []test{
 		},
 		want: []dto.Report{
 			{
-				GameName:   "game_001",
-				TotalKills: 1,
-				Players:    []string{"Test1", "Test2"},
+				GameName:    "game_001",
+				EndReason:   "truncated",
+				StartTime:   "0:00",
+				EndTime:     "22:06",
+				Duration:    1326,
+				MatchConfig: initGameMatchConfig,
+				TotalKills:  1,
+				Players:     []string{"Test1", "Test2"},
 				Kills: map[string]int{
 					"Test2": 1,
 				},
 				KillsByMeans: map[string]int{
 					"MOD_ROCKET_SPLASH": 1,
 				},
+				PlayerStats: map[string]dto.PlayerStats{
+					"Test1": {Deaths: 1, LongestLife: 1326, KillsByMeans: map[string]int{}, DeathsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 1}},
+					"Test2": {Frags: 1, NetScore: 1, KDRatio: 1, LongestStreak: 1, LongestLife: 1326, KillsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 1}, DeathsByMeans: map[string]int{}},
+				},
+				HeadToHead: dto.HeadToHead{
+					"Test2": {"Test1": 1},
+				},
+				FirstBlood: &dto.FirstBlood{Killer: "Test2", Victim: "Test1", Time: "22:06", Elapsed: 1326},
 			},
 		},
 	},
@@ -223,15 +318,28 @@ var processKillEventTests = []test{
 		},
 		want: []dto.Report{
 			{
-				GameName:   "game_001",
-				TotalKills: 1,
-				Players:    []string{"Test1", "Test2"},
+				GameName:    "game_001",
+				EndReason:   "truncated",
+				StartTime:   "0:00",
+				EndTime:     "22:06",
+				Duration:    1326,
+				MatchConfig: initGameMatchConfig,
+				TotalKills:  1,
+				Players:     []string{"Test1", "Test2"},
 				Kills: map[string]int{
 					"Test2": 1,
 				},
 				KillsByMeans: map[string]int{
 					"MOD_ROCKET_SPLASH": 1,
 				},
+				PlayerStats: map[string]dto.PlayerStats{
+					"Test1": {Deaths: 1, LongestLife: 1326, KillsByMeans: map[string]int{}, DeathsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 1}},
+					"Test2": {Frags: 1, NetScore: 1, KDRatio: 1, LongestStreak: 1, LongestLife: 1326, KillsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 1}, DeathsByMeans: map[string]int{}},
+				},
+				HeadToHead: dto.HeadToHead{
+					"Test2": {"Test1": 1},
+				},
+				FirstBlood: &dto.FirstBlood{Killer: "Test2", Victim: "Test1", Time: "22:06", Elapsed: 1326},
 			},
 		},
 	},
@@ -247,15 +355,23 @@ var processKillEventTests = []test{
 		},
 		want: []dto.Report{
 			{
-				GameName:   "game_001",
-				TotalKills: 1,
-				Players:    []string{"Test1", "Test2"},
+				GameName:    "game_001",
+				EndReason:   "truncated",
+				StartTime:   "0:00",
+				EndTime:     "22:06",
+				Duration:    1326,
+				MatchConfig: initGameMatchConfig,
+				TotalKills:  1,
+				Players:     []string{"Test1", "Test2"},
 				Kills: map[string]int{
 					"Test1": -1,
 				},
 				KillsByMeans: map[string]int{
 					"MOD_TRIGGER_HURT": 1,
 				},
+				PlayerStats: map[string]dto.PlayerStats{
+					"Test1": {Deaths: 1, WorldDeaths: 1, NetScore: -1, LongestLife: 1326, KillsByMeans: map[string]int{}, DeathsByMeans: map[string]int{"MOD_TRIGGER_HURT": 1}},
+				},
 			},
 		},
 	},
@@ -270,10 +386,15 @@ var processKillEventTests = []test{
 		},
 		want: []dto.Report{
 			{
-				GameName:   "game_001",
-				TotalKills: 1,
-				Players:    []string{"Test1"},
-				Kills:      make(map[string]int),
+				GameName:    "game_001",
+				EndReason:   "truncated",
+				StartTime:   "0:00",
+				EndTime:     "22:06",
+				Duration:    1326,
+				MatchConfig: initGameMatchConfig,
+				TotalKills:  1,
+				Players:     []string{"Test1"},
+				Kills:       make(map[string]int),
 				KillsByMeans: map[string]int{
 					"MOD_ROCKET_SPLASH": 1,
 				},
@@ -282,8 +403,121 @@ var processKillEventTests = []test{
 	},
 }
 
+var processEndOfGameTests = []test{
+	{
+		name: "should set the end reason from the exit event and mark the game as complete",
+		args: args{
+			lines: []string{
+				initGameEvent,
+				userTest1Event,
+				`11:57 Exit: Fraglimit hit.`,
+				`12:13 ShutdownGame:`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:     "game_001",
+				EndReason:    "fraglimit",
+				StartTime:    "0:00",
+				EndTime:      "12:13",
+				Duration:     733,
+				Complete:     true,
+				MatchConfig:  initGameMatchConfig,
+				Players:      []string{"Test1"},
+				Kills:        make(map[string]int),
+				KillsByMeans: make(map[string]int),
+			},
+		},
+	},
+	{
+		name: "should set shutdown as end reason if there is no exit event",
+		args: args{
+			lines: []string{
+				initGameEvent,
+				userTest1Event,
+				`12:13 ShutdownGame:`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:     "game_001",
+				EndReason:    "shutdown",
+				StartTime:    "0:00",
+				EndTime:      "12:13",
+				Duration:     733,
+				MatchConfig:  initGameMatchConfig,
+				Players:      []string{"Test1"},
+				Kills:        make(map[string]int),
+				KillsByMeans: make(map[string]int),
+			},
+		},
+	},
+	{
+		name: "should set truncated as end reason if a new game starts before the end",
+		args: args{
+			lines: []string{
+				initGameEvent,
+				userTest1Event,
+				initGameEvent,
+				userTest2Event,
+				`11:15 Exit: Timelimit hit.`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:     "game_001",
+				EndReason:    "truncated",
+				StartTime:    "0:00",
+				EndTime:      "20:34",
+				Duration:     1234,
+				MatchConfig:  initGameMatchConfig,
+				Players:      []string{"Test1"},
+				Kills:        make(map[string]int),
+				KillsByMeans: make(map[string]int),
+			},
+			{
+				GameName:     "game_002",
+				EndReason:    "timelimit",
+				StartTime:    "0:00",
+				EndTime:      "11:15",
+				Duration:     675,
+				Complete:     true,
+				MatchConfig:  initGameMatchConfig,
+				Players:      []string{"Test2"},
+				Kills:        make(map[string]int),
+				KillsByMeans: make(map[string]int),
+			},
+		},
+	},
+	{
+		name: "should keep the end time of a game aborted by a restart of the server",
+		args: args{
+			lines: []string{
+				`20:37 InitGame: \sv_floodProtect\1\sv_maxPing\0\sv_minPing\0\`,
+				`20:38 ClientUserinfoChanged: 2 n\Test1\t\0\model\xian/default\hmodel\`,
+				`26:09 ClientUserinfoChanged: 2 n\Test1\t\0\model\xian/default\hmodel\`,
+				`  0:00 ------------------------------------------------------------`,
+				`  0:00 InitGame: \sv_floodProtect\1\sv_maxPing\0\sv_minPing\0\`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:     "game_001",
+				EndReason:    "truncated",
+				StartTime:    "20:37",
+				EndTime:      "26:09",
+				Duration:     332,
+				MatchConfig:  initGameMatchConfig,
+				Players:      []string{"Test1"},
+				Kills:        make(map[string]int),
+				KillsByMeans: make(map[string]int),
+			},
+		},
+	},
+}
+
 func TestQuakeService_StartExtractingData(t *testing.T) {
-	svc := getQuakeService(t)
+	svc := getQuakeService(t, config.GetDefaultConfig())
 	ctx := context.Background()
 
 	tests := []test{}
@@ -293,6 +527,17 @@ func TestQuakeService_StartExtractingData(t *testing.T) {
 		processNewGameEventTests,
 		processUserChangedEventTests,
 		processKillEventTests,
+		processEndOfGameTests,
+		processScoreEventTests,
+		processTeamEventTests,
+		processCTFEventTests,
+		processItemEventTests,
+		processKillStreakEventTests,
+		processIdentityEventTests,
+		processSessionEventTests,
+		processChatEventTests,
+		processTimelineEventTests,
+		processScoreProgressionTests,
 	)
 
 	tests = append(tests,
@@ -306,7 +551,7 @@ func TestQuakeService_StartExtractingData(t *testing.T) {
 			want: []dto.Report{},
 		},
 		test{
-			name: "item line should be skipped and not processed",
+			name: "should not report a game without players even if it has item pickups",
 			args: args{
 				lines: []string{
 					initGameEvent,
@@ -323,8 +568,13 @@ func TestQuakeService_StartExtractingData(t *testing.T) {
 				tt.setupTest(tt.args)
 			}
 
+			svc := svc
+			if tt.cfg != nil {
+				svc = getQuakeService(t, tt.cfg)
+			}
+
 			writerChan := make(chan dto.Report)
-			lineChan := make(chan string)
+			lineChan := make(chan dto.LogLine)
 
 			wg := sync.WaitGroup{}
 			wg.Add(1)
@@ -344,21 +594,23 @@ func TestQuakeService_StartExtractingData(t *testing.T) {
 			}()
 
 			for i := range tt.args.lines {
-				lineChan <- tt.args.lines[i]
+				lineChan <- dto.LogLine{Text: tt.args.lines[i], Number: i + 1}
 			}
 			close(lineChan)
 
+			wg.Wait()
+
 			if len(tt.args.lines) == 0 {
-				assert.Equal(t, 0, len(writerChan))
+				assert.Equal(t, 0, len(reports))
 				return
 			}
 
-			wg.Wait()
-
 			require.Equal(t, len(tt.want), len(reports))
 			for i := range reports {
 				sort.Strings(reports[i].Players)
 				sort.Strings(tt.want[i].Players)
+				// the fingerprint is a hash of the game, it is checked by the tests of the rating
+				reports[i].Fingerprint = ""
 				require.Equal(t, tt.want[i], reports[i])
 			}
 
@@ -366,3 +618,31 @@ func TestQuakeService_StartExtractingData(t *testing.T) {
 		})
 	}
 }
+
+func TestQuakeService_StartExtractingData_Fingerprint(t *testing.T) {
+	lines := logLines(
+		initGameEvent,
+		userTest1Event,
+		userTest2Event,
+		killEvent,
+		`22:07 Kill: 2 3 6: Test1 killed Test2 by MOD_ROCKET`,
+		`22:08 Exit: Fraglimit hit.`,
+	)
+
+	_, reports, err := extractData(t, config.GetDefaultConfig(), lines)
+	require.NoError(t, err)
+
+	cfg := config.GetDefaultConfig()
+	cfg.Aliases = map[string]string{"Test1": "Player1"}
+	cfg.GroupByWeapon = true
+	_, flagged, err := extractData(t, cfg, lines)
+	require.NoError(t, err)
+	require.Contains(t, flagged[0].Players, "Player1")
+	require.Equal(t, map[string]int{"rocket_launcher": 2}, flagged[0].KillsByMeans)
+
+	// the same game parsed again with other flags is not rated twice
+	state := dto.NewRatingState()
+	require.Len(t, dto.QuakeDataReport{"game_001": reports[0]}.UpdateRatings(state), 2)
+	require.Empty(t, dto.QuakeDataReport{"game_001": flagged[0]}.UpdateRatings(state))
+	require.Len(t, state.RatedGames, 1)
+}
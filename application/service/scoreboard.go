@@ -0,0 +1,34 @@
+package service
+
+import (
+	"github.com/diegoclair/log-parser/application/dto"
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
+// processScoreEvent is a function that processes the score event and appends it to the official scoreboard of the game,
+// with the player bound to the slot, as the slots can be released by the disconnect lines after the scoreboard.
+func processScoreEvent(event entity.ScoreEvent, gameData *dto.QuakeData) {
+	gameData.Scoreboard = append(gameData.Scoreboard, dto.ScoreLine{ScoreEvent: event, PlayerID: gameData.PlayerID(event.ClientID)})
+}
+
+// reconcileScoreboard is a function that compares the net score of each player, computed like the server score,
+// with the official scoreboard and stores the players with different values as discrepancies.
+// Flag game types are not reconciled, because their score also counts captures, returns and assists.
+func reconcileScoreboard(gameData *dto.QuakeData) {
+	if gameData.Config.GameType >= entity.GameTypeCTF {
+		return
+	}
+
+	for _, score := range gameData.Scoreboard {
+		computed := gameData.NetScore(score.PlayerID)
+		if computed == score.Score {
+			continue
+		}
+
+		gameData.Discrepancies = append(gameData.Discrepancies, dto.ScoreDiscrepancy{
+			Player:   score.Name,
+			Computed: computed,
+			Official: score.Score,
+		})
+	}
+}
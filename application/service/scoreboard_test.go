@@ -0,0 +1,160 @@
+package service_test
+
+import "github.com/diegoclair/log-parser/application/dto"
+
+var processScoreEventTests = []test{
+	{
+		name: "should capture the official scoreboard and flag the discrepancies with the computed kills",
+		args: args{
+			lines: []string{
+				initGameEvent,
+				userTest1Event,
+				userTest2Event,
+				killEvent,
+				`22:10 Exit: Fraglimit hit.`,
+				`22:10 score: 1  ping: 4  client: 3 Test2`,
+				`22:10 score: 0  ping: 9  client: 2 Test1`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:    "game_001",
+				EndReason:   "fraglimit",
+				Complete:    true,
+				StartTime:   "0:00",
+				EndTime:     "22:10",
+				Duration:    1330,
+				MatchConfig: initGameMatchConfig,
+				TotalKills:  1,
+				Players:     []string{"Test1", "Test2"},
+				Kills: map[string]int{
+					"Test2": 1,
+				},
+				KillsByMeans: map[string]int{
+					"MOD_ROCKET_SPLASH": 1,
+				},
+				OfficialScoreboard: []dto.ScoreboardEntry{
+					{Name: "Test2", Player: "Test2", Score: 1, Ping: 4, ClientID: 3},
+					{Name: "Test1", Player: "Test1", Score: 0, Ping: 9, ClientID: 2},
+				},
+				PlayerStats: map[string]dto.PlayerStats{
+					"Test1": {Deaths: 1, LongestLife: 1326, KillsByMeans: map[string]int{}, DeathsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 1}},
+					"Test2": {Frags: 1, NetScore: 1, KDRatio: 1, LongestStreak: 1, LongestLife: 1330, KillsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 1}, DeathsByMeans: map[string]int{}},
+				},
+				HeadToHead: dto.HeadToHead{
+					"Test2": {"Test1": 1},
+				},
+				FirstBlood: &dto.FirstBlood{Killer: "Test2", Victim: "Test1", Time: "22:06", Elapsed: 1326},
+			},
+		},
+	},
+	{
+		name: "should reconcile the suicides with the official scoreboard, as the server removes one point for them",
+		args: args{
+			lines: []string{
+				initGameEvent,
+				userTest1Event,
+				userTest2Event,
+				samePlayerKillEvent,
+				`22:10 Exit: Timelimit hit.`,
+				`22:10 score: 0  ping: 9  client: 2 Test1`,
+				`22:10 score: -1  ping: 4  client: 3 Test2`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:    "game_001",
+				EndReason:   "timelimit",
+				Complete:    true,
+				StartTime:   "0:00",
+				EndTime:     "22:10",
+				Duration:    1330,
+				MatchConfig: initGameMatchConfig,
+				TotalKills:  1,
+				Players:     []string{"Test1", "Test2"},
+				Kills:       make(map[string]int),
+				KillsByMeans: map[string]int{
+					"MOD_ROCKET_SPLASH": 1,
+				},
+				OfficialScoreboard: []dto.ScoreboardEntry{
+					{Name: "Test1", Player: "Test1", Score: 0, Ping: 9, ClientID: 2},
+					{Name: "Test2", Player: "Test2", Score: -1, Ping: 4, ClientID: 3},
+				},
+				PlayerStats: map[string]dto.PlayerStats{
+					"Test2": {Deaths: 1, Suicides: 1, NetScore: -1, LongestLife: 1326, KillsByMeans: map[string]int{}, DeathsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 1}},
+				},
+			},
+		},
+	},
+	{
+		name: "should flag the players whose computed net score differs from the official scoreboard",
+		args: args{
+			lines: []string{
+				initGameEvent,
+				userTest1Event,
+				userTest2Event,
+				samePlayerKillEvent,
+				`22:10 Exit: Timelimit hit.`,
+				`22:10 score: 0  ping: 9  client: 2 Test1`,
+				`22:10 score: 2  ping: 4  client: 3 Test2`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:    "game_001",
+				EndReason:   "timelimit",
+				Complete:    true,
+				StartTime:   "0:00",
+				EndTime:     "22:10",
+				Duration:    1330,
+				MatchConfig: initGameMatchConfig,
+				TotalKills:  1,
+				Players:     []string{"Test1", "Test2"},
+				Kills:       make(map[string]int),
+				KillsByMeans: map[string]int{
+					"MOD_ROCKET_SPLASH": 1,
+				},
+				OfficialScoreboard: []dto.ScoreboardEntry{
+					{Name: "Test1", Player: "Test1", Score: 0, Ping: 9, ClientID: 2},
+					{Name: "Test2", Player: "Test2", Score: 2, Ping: 4, ClientID: 3},
+				},
+				Discrepancies: []dto.ScoreDiscrepancy{
+					{Player: "Test2", Computed: -1, Official: 2},
+				},
+				PlayerStats: map[string]dto.PlayerStats{
+					"Test2": {Deaths: 1, Suicides: 1, NetScore: -1, LongestLife: 1326, KillsByMeans: map[string]int{}, DeathsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 1}},
+				},
+			},
+		},
+	},
+	{
+		name: "should not reconcile the scoreboard of flag game types",
+		args: args{
+			lines: []string{
+				`  0:00 InitGame: \g_gametype\4`,
+				userTest1Event,
+				`22:10 Exit: Capturelimit hit.`,
+				`22:10 score: 77  ping: 3  client: 2 Test1`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:     "game_001",
+				EndReason:    "capturelimit",
+				Complete:     true,
+				StartTime:    "0:00",
+				EndTime:      "22:10",
+				Duration:     1330,
+				MatchConfig:  dto.MatchConfig{GameType: "CTF"},
+				Teams:        emptyTeams,
+				CTF:          emptyCTF,
+				Players:      []string{"Test1"},
+				Kills:        make(map[string]int),
+				KillsByMeans: make(map[string]int),
+				OfficialScoreboard: []dto.ScoreboardEntry{
+					{Name: "Test1", Player: "Test1", Score: 77, Ping: 3, ClientID: 2},
+				},
+			},
+		},
+	},
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"github.com/diegoclair/go_utils/logger"
 	"github.com/diegoclair/log-parser/application/contract"
+	"github.com/diegoclair/log-parser/infra/config"
 )
 
 type Services struct {
@@ -11,12 +12,14 @@ type Services struct {
 
 type service struct {
 	log logger.Logger
+	cfg *config.Config
 }
 
 // New to get instance of all services
-func New(log logger.Logger) (*Services, error) {
+func New(log logger.Logger, cfg *config.Config) (*Services, error) {
 	svc := &service{
 		log: log,
+		cfg: cfg,
 	}
 
 	return &Services{
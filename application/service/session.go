@@ -0,0 +1,31 @@
+package service
+
+import (
+	"github.com/diegoclair/log-parser/application/dto"
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
+// processClientBeginEvent is a function that processes the client begin event and opens a session of the player.
+// The server also prints the begin line when a player changes team, so it is ignored if the player has an open session.
+func processClientBeginEvent(event entity.ClientBeginEvent, gameData *dto.QuakeData) {
+	sessions := gameData.Sessions[event.ClientID]
+	if len(sessions) > 0 && sessions[len(sessions)-1].Open {
+		return
+	}
+
+	gameData.Sessions[event.ClientID] = append(sessions, dto.Session{Join: event.Time, Open: true})
+}
+
+// processClientDisconnectEvent is a function that processes the client disconnect event and closes the open session of the player.
+// A player who disconnects before the exit or the shutdown of the game left it early.
+func processClientDisconnectEvent(event entity.ClientDisconnectEvent, gameData *dto.QuakeData) {
+	sessions := gameData.Sessions[event.ClientID]
+	if len(sessions) == 0 || !sessions[len(sessions)-1].Open {
+		return
+	}
+
+	session := &sessions[len(sessions)-1]
+	session.Leave = event.Time
+	session.Open = false
+	session.LeftEarly = gameData.EndReason == ""
+}
@@ -0,0 +1,96 @@
+package service_test
+
+import "github.com/diegoclair/log-parser/application/dto"
+
+var processSessionEventTests = []test{
+	{
+		name: "should build the sessions of the players and the per-minute stats over the time played",
+		args: args{
+			lines: []string{
+				initGameEvent,
+				`20:34 ClientConnect: 2`,
+				userTest1Event,
+				`20:35 ClientBegin: 2`,
+				`20:34 ClientConnect: 3`,
+				userTest2Event,
+				`20:36 ClientBegin: 3`,
+				`21:00 ClientBegin: 2`,
+				killEvent,
+				`22:30 ClientDisconnect: 2`,
+				`23:00 Exit: Timelimit hit.`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:    "game_001",
+				EndReason:   "timelimit",
+				Complete:    true,
+				StartTime:   "0:00",
+				EndTime:     "23:00",
+				Duration:    1380,
+				MatchConfig: initGameMatchConfig,
+				TotalKills:  1,
+				Players:     []string{"Test1", "Test2"},
+				Kills: map[string]int{
+					"Test2": 1,
+				},
+				KillsByMeans: map[string]int{
+					"MOD_ROCKET_SPLASH": 1,
+				},
+				PlayerStats: map[string]dto.PlayerStats{
+					"Test1": {Deaths: 1, LongestLife: 1326, KillsByMeans: map[string]int{}, DeathsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 1}},
+					"Test2": {Frags: 1, NetScore: 1, KDRatio: 1, LongestStreak: 1, LongestLife: 1380, KillsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 1}, DeathsByMeans: map[string]int{}},
+				},
+				HeadToHead: dto.HeadToHead{
+					"Test2": {"Test1": 1},
+				},
+				FirstBlood: &dto.FirstBlood{Killer: "Test2", Victim: "Test1", Time: "22:06", Elapsed: 1326},
+				Sessions: map[string]dto.PlayerSessions{
+					"Test1": {
+						Sessions:        []dto.SessionReport{{Join: "20:35", Leave: "22:30", Duration: 115, LeftEarly: true}},
+						TimePlayed:      115,
+						LeftEarly:       true,
+						DeathsPerMinute: 0.52,
+					},
+					"Test2": {
+						Sessions:       []dto.SessionReport{{Join: "20:36", Leave: "23:00", Duration: 144}},
+						TimePlayed:     144,
+						KillsPerMinute: 0.42,
+					},
+				},
+			},
+		},
+	},
+	{
+		name: "should not mark a player who disconnects after the exit as left early",
+		args: args{
+			lines: []string{
+				initGameEvent,
+				userTest1Event,
+				`20:35 ClientBegin: 2`,
+				`21:00 Exit: Timelimit hit.`,
+				`21:01 ClientDisconnect: 2`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:     "game_001",
+				EndReason:    "timelimit",
+				Complete:     true,
+				StartTime:    "0:00",
+				EndTime:      "21:01",
+				Duration:     1261,
+				MatchConfig:  initGameMatchConfig,
+				Players:      []string{"Test1"},
+				Kills:        make(map[string]int),
+				KillsByMeans: make(map[string]int),
+				Sessions: map[string]dto.PlayerSessions{
+					"Test1": {
+						Sessions:   []dto.SessionReport{{Join: "20:35", Leave: "21:01", Duration: 26}},
+						TimePlayed: 26,
+					},
+				},
+			},
+		},
+	},
+}
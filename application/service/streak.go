@@ -0,0 +1,65 @@
+package service
+
+import (
+	"time"
+
+	"github.com/diegoclair/log-parser/application"
+	"github.com/diegoclair/log-parser/application/dto"
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
+// minEndedStreak is the minimum number of kills of a streak to report who ended it.
+const minEndedStreak = 3
+
+// processKillStreakEvent is a function that processes the kill event and updates the streaks, multi-kills and first blood of the gameData.
+// Consecutive kills of a player within the multiKillWindow of each other are counted as a single multi-kill.
+func processKillStreakEvent(event entity.KillEvent, gameData *dto.QuakeData, multiKillWindow time.Duration) {
+	// any death ends the streak of the killed player, including suicides and world kills
+	killedStats := gameData.GetPlayerStats(event.KilledID)
+	if killedStats.Streak >= minEndedStreak {
+		gameData.EndedStreaks = append(gameData.EndedStreaks, dto.EndedStreak{
+			PlayerID:  event.KilledID,
+			Streak:    killedStats.Streak,
+			EndedByID: event.KillerID,
+			Time:      event.Time,
+		})
+	}
+	killedStats.Streak = 0
+
+	// only the frags count for streaks, like for the kills of the player
+	if event.KillerID == application.WorldPlayerID || event.KillerID == event.KilledID || isTeamKill(event, gameData) {
+		return
+	}
+
+	if gameData.FirstBlood == nil {
+		gameData.FirstBlood = &event
+	}
+
+	killerStats := gameData.GetPlayerStats(event.KillerID)
+	killerStats.Streak++
+	killerStats.LongestStreak = max(killerStats.LongestStreak, killerStats.Streak)
+
+	if killerStats.MultiKill > 0 && event.Time.Elapsed(killerStats.LastKillTime) <= multiKillWindow {
+		killerStats.MultiKill++
+	} else {
+		killerStats.MultiKill = 1
+	}
+	killerStats.LastKillTime = event.Time
+
+	if killerStats.MultiKill < 2 {
+		return
+	}
+
+	if killerStats.MultiKills == nil {
+		killerStats.MultiKills = make(map[int]int)
+	}
+
+	// a growing multi-kill replaces the smaller one counted on the previous kill
+	killerStats.MultiKills[killerStats.MultiKill]++
+	if previous := killerStats.MultiKill - 1; previous >= 2 {
+		killerStats.MultiKills[previous]--
+		if killerStats.MultiKills[previous] == 0 {
+			delete(killerStats.MultiKills, previous)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package service_test
+
+import "github.com/diegoclair/log-parser/application/dto"
+
+var processKillStreakEventTests = []test{
+	{
+		name: "should compute the first blood, the longest streaks, the multi-kills and who ended the streaks",
+		args: args{
+			lines: []string{
+				initGameEvent,
+				userTest1Event,
+				userTest2Event,
+				userRedTest3Event,
+				` 1:00 Kill: 3 2 7: Test2 killed Test1 by MOD_ROCKET_SPLASH`,
+				` 1:02 Kill: 3 4 7: Test2 killed Test3 by MOD_ROCKET_SPLASH`,
+				` 1:03 Kill: 3 2 7: Test2 killed Test1 by MOD_ROCKET_SPLASH`,
+				` 1:10 Kill: 2 3 10: Test1 killed Test2 by MOD_RAILGUN`,
+				` 1:11 Kill: 1022 2 22: <world> killed Test1 by MOD_TRIGGER_HURT`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:    "game_001",
+				EndReason:   "truncated",
+				StartTime:   "0:00",
+				EndTime:     "1:11",
+				Duration:    71,
+				MatchConfig: initGameMatchConfig,
+				TotalKills:  5,
+				Players:     []string{"Test1", "Test2", "Test3"},
+				Kills: map[string]int{
+					"Test1": 0,
+					"Test2": 3,
+				},
+				KillsByMeans: map[string]int{
+					"MOD_ROCKET_SPLASH": 3,
+					"MOD_RAILGUN":       1,
+					"MOD_TRIGGER_HURT":  1,
+				},
+				PlayerStats: map[string]dto.PlayerStats{
+					"Test1": {Frags: 1, Deaths: 3, WorldDeaths: 1, KDRatio: 0.33, LongestStreak: 1, LongestLife: 60, KillsByMeans: map[string]int{"MOD_RAILGUN": 1}, DeathsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 2, "MOD_TRIGGER_HURT": 1}},
+					"Test2": {Frags: 3, Deaths: 1, NetScore: 3, KDRatio: 3, LongestStreak: 3, LongestLife: 70, MultiKills: map[int]int{3: 1}, KillsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 3}, DeathsByMeans: map[string]int{"MOD_RAILGUN": 1}},
+					"Test3": {Deaths: 1, LongestLife: 62, KillsByMeans: map[string]int{}, DeathsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 1}},
+				},
+				HeadToHead: dto.HeadToHead{
+					"Test1": {"Test2": 1},
+					"Test2": {"Test1": 2, "Test3": 1},
+				},
+				FirstBlood: &dto.FirstBlood{Killer: "Test2", Victim: "Test1", Time: "1:00", Elapsed: 60},
+				EndedStreaks: []dto.StreakEnd{
+					{Player: "Test2", Streak: 3, EndedBy: "Test1", Time: "1:10"},
+				},
+			},
+		},
+	},
+	{
+		name: "should split the multi-kills by the window and attribute the end of a streak to the player on a suicide",
+		args: args{
+			lines: []string{
+				initGameEvent,
+				userTest1Event,
+				userTest2Event,
+				userRedTest3Event,
+				` 1:00 Kill: 3 2 7: Test2 killed Test1 by MOD_ROCKET_SPLASH`,
+				` 1:02 Kill: 3 4 7: Test2 killed Test3 by MOD_ROCKET_SPLASH`,
+				` 1:10 Kill: 3 2 7: Test2 killed Test1 by MOD_ROCKET_SPLASH`,
+				` 1:11 Kill: 3 4 7: Test2 killed Test3 by MOD_ROCKET_SPLASH`,
+				` 1:12 Kill: 3 3 7: Test2 killed Test2 by MOD_ROCKET_SPLASH`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:    "game_001",
+				EndReason:   "truncated",
+				StartTime:   "0:00",
+				EndTime:     "1:12",
+				Duration:    72,
+				MatchConfig: initGameMatchConfig,
+				TotalKills:  5,
+				Players:     []string{"Test1", "Test2", "Test3"},
+				Kills: map[string]int{
+					"Test2": 4,
+				},
+				KillsByMeans: map[string]int{
+					"MOD_ROCKET_SPLASH": 5,
+				},
+				PlayerStats: map[string]dto.PlayerStats{
+					"Test1": {Deaths: 2, LongestLife: 60, KillsByMeans: map[string]int{}, DeathsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 2}},
+					"Test2": {Frags: 4, Deaths: 1, Suicides: 1, NetScore: 3, KDRatio: 4, LongestStreak: 4, LongestLife: 72, MultiKills: map[int]int{2: 2}, KillsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 4}, DeathsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 1}},
+					"Test3": {Deaths: 2, LongestLife: 62, KillsByMeans: map[string]int{}, DeathsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 2}},
+				},
+				HeadToHead: dto.HeadToHead{
+					"Test2": {"Test1": 2, "Test3": 2},
+				},
+				FirstBlood: &dto.FirstBlood{Killer: "Test2", Victim: "Test1", Time: "1:00", Elapsed: 60},
+				EndedStreaks: []dto.StreakEnd{
+					{Player: "Test2", Streak: 4, EndedBy: "Test2", Time: "1:12"},
+				},
+			},
+		},
+	},
+}
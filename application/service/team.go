@@ -0,0 +1,22 @@
+package service
+
+import (
+	"github.com/diegoclair/log-parser/application/dto"
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
+// processTeamScoreEvent is a function that processes the team score event and stores the final red and blue scores.
+func processTeamScoreEvent(event entity.TeamScoreEvent, gameData *dto.QuakeData) {
+	gameData.TeamScore = &event
+}
+
+// isTeamKill returns true if the killer and the killed player are on the same team of a team game.
+func isTeamKill(event entity.KillEvent, gameData *dto.QuakeData) bool {
+	if !gameData.Config.GameType.IsTeamGame() {
+		return false
+	}
+
+	killerTeam := gameData.Teams[event.KillerID]
+
+	return killerTeam.IsPlaying() && killerTeam == gameData.Teams[event.KilledID]
+}
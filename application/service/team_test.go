@@ -0,0 +1,119 @@
+package service_test
+
+import "github.com/diegoclair/log-parser/application/dto"
+
+var (
+	tdmInitGameEvent   = `  0:00 InitGame: \g_gametype\3`
+	userRedTest1Event  = `20:34 ClientUserinfoChanged: 2 n\Test1\t\1\model\xian/default\hmodel\`
+	userRedTest3Event  = `20:34 ClientUserinfoChanged: 4 n\Test3\t\1\model\xian/default\hmodel\`
+	userBlueTest2Event = `20:34 ClientUserinfoChanged: 3 n\Test2\t\2\model\sarge/default\hmodel\`
+)
+
+func intPointer(value int) *int {
+	return &value
+}
+
+var processTeamEventTests = []test{
+	{
+		name: "should build the team rosters, count team kills on the kills and separately, and set the team scores",
+		args: args{
+			lines: []string{
+				tdmInitGameEvent,
+				userRedTest1Event,
+				userBlueTest2Event,
+				userRedTest3Event,
+				killEvent,
+				`22:07 Kill: 2 4 7: Test1 killed Test3 by MOD_ROCKET_SPLASH`,
+				`22:08 Kill: 4 3 10: Test3 killed Test2 by MOD_RAILGUN`,
+				`22:10 Exit: Timelimit hit.`,
+				`22:10 red:1  blue:1`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:    "game_001",
+				EndReason:   "timelimit",
+				Complete:    true,
+				StartTime:   "0:00",
+				EndTime:     "22:10",
+				Duration:    1330,
+				MatchConfig: dto.MatchConfig{GameType: "TDM"},
+				TotalKills:  3,
+				Players:     []string{"Test1", "Test2", "Test3"},
+				Kills: map[string]int{
+					"Test1": 1,
+					"Test2": 1,
+					"Test3": 1,
+				},
+				KillsByMeans: map[string]int{
+					"MOD_ROCKET_SPLASH": 2,
+					"MOD_RAILGUN":       1,
+				},
+				Teams: map[string]dto.TeamReport{
+					"red": {
+						Players:   []string{"Test1", "Test3"},
+						Kills:     2,
+						TeamKills: 1,
+						Score:     intPointer(1),
+					},
+					"blue": {
+						Players: []string{"Test2"},
+						Kills:   1,
+						Score:   intPointer(1),
+					},
+				},
+				TeamKills: map[string]int{
+					"Test1": 1,
+				},
+				PlayerStats: map[string]dto.PlayerStats{
+					"Test1": {Deaths: 1, NetScore: -1, LongestLife: 1326, KillsByMeans: map[string]int{}, DeathsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 1}},
+					"Test2": {Frags: 1, Deaths: 1, NetScore: 1, KDRatio: 1, LongestStreak: 1, LongestLife: 1328, KillsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 1}, DeathsByMeans: map[string]int{"MOD_RAILGUN": 1}},
+					"Test3": {Frags: 1, Deaths: 1, NetScore: 1, KDRatio: 1, LongestStreak: 1, LongestLife: 1327, KillsByMeans: map[string]int{"MOD_RAILGUN": 1}, DeathsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 1}},
+				},
+				HeadToHead: dto.HeadToHead{
+					"Test1": {"Test3": 1},
+					"Test2": {"Test1": 1},
+					"Test3": {"Test2": 1},
+				},
+				FirstBlood: &dto.FirstBlood{Killer: "Test2", Victim: "Test1", Time: "22:06", Elapsed: 1326},
+			},
+		},
+	},
+	{
+		name: "should not count team kills on non team game types",
+		args: args{
+			lines: []string{
+				initGameEvent,
+				userRedTest1Event,
+				userRedTest3Event,
+				`22:07 Kill: 2 4 7: Test1 killed Test3 by MOD_ROCKET_SPLASH`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:    "game_001",
+				EndReason:   "truncated",
+				StartTime:   "0:00",
+				EndTime:     "22:07",
+				Duration:    1327,
+				MatchConfig: initGameMatchConfig,
+				TotalKills:  1,
+				Players:     []string{"Test1", "Test3"},
+				Kills: map[string]int{
+					"Test1": 1,
+				},
+				KillsByMeans: map[string]int{
+					"MOD_ROCKET_SPLASH": 1,
+				},
+				PlayerStats: map[string]dto.PlayerStats{
+					"Test1": {Frags: 1, NetScore: 1, KDRatio: 1, LongestStreak: 1, LongestLife: 1327, KillsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 1}, DeathsByMeans: map[string]int{}},
+					"Test3": {Deaths: 1, LongestLife: 1327, KillsByMeans: map[string]int{}, DeathsByMeans: map[string]int{"MOD_ROCKET_SPLASH": 1}},
+				},
+				HeadToHead: dto.HeadToHead{
+					"Test1": {"Test3": 1},
+				},
+				FirstBlood: &dto.FirstBlood{Killer: "Test1", Victim: "Test3", Time: "22:07", Elapsed: 1327},
+			},
+		},
+	},
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"github.com/diegoclair/log-parser/application/dto"
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
+// recordTimelineEvent is a function that appends the event to the timeline of the game, with the names of the players at the time of the event.
+// The user info lines are only recorded when the player joins or changes team, and the events without meaning for a replay are skipped.
+func recordTimelineEvent(event entity.Event, gameData *dto.QuakeData) {
+	timelineEvent := dto.TimelineEvent{
+		Time:    event.Timestamp().String(),
+		Elapsed: int(event.Timestamp().Elapsed(gameData.StartTime).Seconds()),
+	}
+
+	switch e := event.(type) {
+	case entity.ClientConnectEvent:
+		timelineEvent.Type = dto.TimelineEventConnect
+		timelineEvent.Slot = &e.ClientID
+	case entity.ClientUserinfoChangedEvent:
+		team, ok := gameData.Teams[e.ClientID]
+		switch {
+		case !ok:
+			timelineEvent.Type = dto.TimelineEventJoin
+		case team != e.Team:
+			timelineEvent.Type = dto.TimelineEventTeamChange
+		default:
+			return
+		}
+		timelineEvent.Player = e.Name
+		timelineEvent.Team = e.Team.String()
+	case entity.ClientBeginEvent:
+		timelineEvent.Type = dto.TimelineEventBegin
+		timelineEvent.Player = gameData.Players[e.ClientID]
+	case entity.ClientDisconnectEvent:
+		timelineEvent.Type = dto.TimelineEventDisconnect
+		timelineEvent.Player = gameData.Players[e.ClientID]
+	case entity.ItemEvent:
+		timelineEvent.Type = dto.TimelineEventItem
+		timelineEvent.Player = gameData.Players[e.ClientID]
+		timelineEvent.Item = e.Item
+	case entity.KillEvent:
+		timelineEvent.Type = dto.TimelineEventKill
+		timelineEvent.Player = gameData.PlayerName(e.KillerID)
+		timelineEvent.Victim = gameData.Players[e.KilledID]
+		timelineEvent.Mean = e.DeathCause
+	case entity.CTFEvent:
+		timelineEvent.Type = dto.TimelineEventCTF
+		timelineEvent.Player = gameData.Players[e.ClientID]
+		timelineEvent.Team = e.Team.String()
+		timelineEvent.Action = e.Action.String()
+	case entity.SayEvent:
+		timelineEvent.Type = dto.TimelineEventChat
+		timelineEvent.Player = e.Name
+		timelineEvent.Message = e.Message
+	case entity.ExitEvent:
+		timelineEvent.Type = dto.TimelineEventExit
+		timelineEvent.Reason = string(e.EndReason())
+	case entity.ShutdownGameEvent:
+		timelineEvent.Type = dto.TimelineEventShutdown
+	case entity.ScoreEvent:
+		timelineEvent.Type = dto.TimelineEventScore
+		timelineEvent.Player = e.Name
+		timelineEvent.Score = &e.Score
+	case entity.TeamScoreEvent:
+		red, blue := timelineEvent, timelineEvent
+		red.Type, red.Team, red.Score = dto.TimelineEventTeamScore, entity.TeamRed.String(), &e.Red
+		blue.Type, blue.Team, blue.Score = dto.TimelineEventTeamScore, entity.TeamBlue.String(), &e.Blue
+		gameData.Timeline = append(gameData.Timeline, red, blue)
+		return
+	default:
+		return
+	}
+
+	gameData.Timeline = append(gameData.Timeline, timelineEvent)
+}
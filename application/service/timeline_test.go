@@ -0,0 +1,103 @@
+package service_test
+
+import (
+	"github.com/diegoclair/log-parser/application/dto"
+	"github.com/diegoclair/log-parser/infra/config"
+)
+
+var (
+	timelineSlot  = 2
+	timelineScore = -1
+	redScore      = 3
+	blueScore     = 1
+)
+
+var processTimelineEventTests = []test{
+	{
+		name: "should record the timeline of the game when it is enabled",
+		cfg:  &config.Config{Timeline: true},
+		args: args{
+			lines: []string{
+				initGameEvent,
+				`20:34 ClientConnect: 2`,
+				userTest1Event,
+				`20:35 ClientBegin: 2`,
+				`20:36 ClientUserinfoChanged: 2 n\Test1\t\0\model\xian/default\hmodel\`,
+				`20:50 Kill: 1022 2 22: <world> killed Test1 by MOD_TRIGGER_HURT`,
+				`20:55 say: Test1: gg`,
+				`21:00 Exit: Fraglimit hit.`,
+				`21:00 score: -1  ping: 4  client: 2 Test1`,
+				`21:01 ShutdownGame:`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:     "game_001",
+				EndReason:    "fraglimit",
+				Complete:     true,
+				StartTime:    "0:00",
+				EndTime:      "21:01",
+				Duration:     1261,
+				MatchConfig:  initGameMatchConfig,
+				TotalKills:   1,
+				Players:      []string{"Test1"},
+				Kills:        map[string]int{"Test1": -1},
+				KillsByMeans: map[string]int{"MOD_TRIGGER_HURT": 1},
+				OfficialScoreboard: []dto.ScoreboardEntry{
+					{Name: "Test1", Player: "Test1", Score: -1, Ping: 4, ClientID: 2},
+				},
+				PlayerStats: map[string]dto.PlayerStats{
+					"Test1": {Deaths: 1, WorldDeaths: 1, NetScore: -1, LongestLife: 1250, KillsByMeans: map[string]int{}, DeathsByMeans: map[string]int{"MOD_TRIGGER_HURT": 1}},
+				},
+				Sessions: map[string]dto.PlayerSessions{
+					"Test1": {
+						Sessions:        []dto.SessionReport{{Join: "20:35", Leave: "21:01", Duration: 26}},
+						TimePlayed:      26,
+						DeathsPerMinute: 2.31,
+					},
+				},
+				Timeline: []dto.TimelineEvent{
+					{Time: "20:34", Elapsed: 1234, Type: dto.TimelineEventConnect, Slot: &timelineSlot},
+					{Time: "20:34", Elapsed: 1234, Type: dto.TimelineEventJoin, Player: "Test1", Team: "free"},
+					{Time: "20:35", Elapsed: 1235, Type: dto.TimelineEventBegin, Player: "Test1"},
+					{Time: "20:50", Elapsed: 1250, Type: dto.TimelineEventKill, Player: "<world>", Victim: "Test1", Mean: "MOD_TRIGGER_HURT"},
+					{Time: "20:55", Elapsed: 1255, Type: dto.TimelineEventChat, Player: "Test1", Message: "gg"},
+					{Time: "21:00", Elapsed: 1260, Type: dto.TimelineEventExit, Reason: "fraglimit"},
+					{Time: "21:00", Elapsed: 1260, Type: dto.TimelineEventScore, Player: "Test1", Score: &timelineScore},
+					{Time: "21:01", Elapsed: 1261, Type: dto.TimelineEventShutdown},
+				},
+			},
+		},
+	},
+	{
+		name: "should record the team changes and the team scores",
+		cfg:  &config.Config{Timeline: true},
+		args: args{
+			lines: []string{
+				initGameEvent,
+				`20:34 ClientUserinfoChanged: 2 n\Test1\t\1\model\xian/default\hmodel\`,
+				`20:40 ClientUserinfoChanged: 2 n\Test1\t\2\model\xian/default\hmodel\`,
+				`20:50 red:3  blue:1`,
+			},
+		},
+		want: []dto.Report{
+			{
+				GameName:     "game_001",
+				EndReason:    "truncated",
+				StartTime:    "0:00",
+				EndTime:      "20:50",
+				Duration:     1250,
+				MatchConfig:  initGameMatchConfig,
+				Players:      []string{"Test1"},
+				Kills:        make(map[string]int),
+				KillsByMeans: make(map[string]int),
+				Timeline: []dto.TimelineEvent{
+					{Time: "20:34", Elapsed: 1234, Type: dto.TimelineEventJoin, Player: "Test1", Team: "red"},
+					{Time: "20:40", Elapsed: 1240, Type: dto.TimelineEventTeamChange, Player: "Test1", Team: "blue"},
+					{Time: "20:50", Elapsed: 1250, Type: dto.TimelineEventTeamScore, Team: "red", Score: &redScore},
+					{Time: "20:50", Elapsed: 1250, Type: dto.TimelineEventTeamScore, Team: "blue", Score: &blueScore},
+				},
+			},
+		},
+	},
+}
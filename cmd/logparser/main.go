@@ -2,13 +2,22 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"flag"
+	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
+	utilslogger "github.com/diegoclair/go_utils/logger"
 	"github.com/diegoclair/log-parser/application/dto"
 	"github.com/diegoclair/log-parser/application/service"
+	"github.com/diegoclair/log-parser/domain/entity"
 	"github.com/diegoclair/log-parser/infra/config"
 	"github.com/diegoclair/log-parser/infra/logger"
 	"github.com/diegoclair/log-parser/infra/writer"
@@ -16,11 +25,37 @@ import (
 )
 
 var (
-	logPath string
+	logPath          string
+	headToHeadPlayer string
+	multiKillWindow  time.Duration
+	awardsPath       string
+	aliasesPath      string
+	ratingsPath      string
+	chat             bool
+	wordListPath     string
+	timelineDir      string
+	timelineFormat   string
+	scoreInterval    time.Duration
+	groupByWeapon    bool
+	strict           bool
+	workers          int
 )
 
 func init() {
-	flag.StringVar(&logPath, "logpath", "./qgames.log", "Quake log file path")
+	flag.StringVar(&logPath, "logpath", "./qgames.log", "Quake log file path, or several paths separated by comma")
+	flag.StringVar(&headToHeadPlayer, "h2h", "", "Player name to print the head-to-head kills against every opponent, across all matches")
+	flag.StringVar(&awardsPath, "awards", "", "Awards file path, in YAML or JSON, to evaluate the awards of each match and of all the matches")
+	flag.StringVar(&aliasesPath, "aliases", "", "Aliases file path, in YAML or JSON, mapping several player names to one canonical name")
+	flag.StringVar(&ratingsPath, "ratings", "", "Rating table file path, the ratings are read from it if it exists and saved to it after the run")
+	flag.BoolVar(&chat, "chat", false, "Add the chat messages to the report of each match")
+	flag.StringVar(&wordListPath, "wordlist", "", "Word list file path, one word per line, to flag the chat messages on the moderation report")
+	flag.StringVar(&timelineDir, "timeline", "", "Directory to write the event timeline of each match, one game_NNN file per match")
+	flag.StringVar(&timelineFormat, "timeline-format", "json", "Format of the timeline files: json for a JSON array, or ndjson for one event per line")
+	flag.BoolVar(&strict, "strict", false, "Abort with a non-zero exit code on the first malformed line of the logs")
+	flag.BoolVar(&groupByWeapon, "by-weapon", false, "Aggregate the kills and deaths by weapon instead of by MOD_* mean")
+	flag.DurationVar(&scoreInterval, "score-interval", 0, "Time between the samples of the score progression of each match, which is only reported if greater than zero")
+	flag.IntVar(&workers, "workers", 0, "Number of games parsed concurrently (default the number of CPUs)")
+	flag.DurationVar(&multiKillWindow, "multikill-window", 0, "Maximum time between two kills of a player to count them as a multi-kill (default from config)")
 }
 
 func main() {
@@ -29,17 +64,73 @@ func main() {
 	start := time.Now()
 
 	cfg := config.GetDefaultConfig()
+	if multiKillWindow > 0 {
+		cfg.MultiKillWindow = multiKillWindow
+	}
+	cfg.Chat = chat
+	cfg.Timeline = timelineDir != ""
+	cfg.ScoreInterval = scoreInterval
+	cfg.GroupByWeapon = groupByWeapon
+	cfg.Strict = strict
+	if workers > 0 {
+		cfg.Workers = workers
+	}
 
-	ctx := context.Background()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 	log := logger.New(cfg)
 
-	logFile, err := os.Open(logPath)
-	if err != nil {
-		log.Errorf(ctx, "Error to open file: %v", err)
-		return
+	if awardsPath != "" {
+		awards, err := config.LoadAwards(awardsPath)
+		if err != nil {
+			log.Errorf(ctx, "Error to load awards: %v", err)
+			return
+		}
+		cfg.Awards = awards
 	}
 
-	defer logFile.Close()
+	if aliasesPath != "" {
+		aliases, err := config.LoadAliases(aliasesPath)
+		if err != nil {
+			log.Errorf(ctx, "Error to load aliases: %v", err)
+			return
+		}
+		cfg.Aliases = aliases
+	}
+
+	if wordListPath != "" {
+		words, err := config.LoadWordList(wordListPath)
+		if err != nil {
+			log.Errorf(ctx, "Error to load word list: %v", err)
+			return
+		}
+		cfg.WordFilter = entity.NewWordFilter(words)
+	}
+
+	if cfg.Timeline {
+		if timelineFormat != "json" && timelineFormat != "ndjson" {
+			log.Errorf(ctx, "Invalid timeline format: %q", timelineFormat)
+			return
+		}
+
+		if err := os.MkdirAll(timelineDir, 0o755); err != nil {
+			log.Errorf(ctx, "Error to create the timeline directory: %v", err)
+			return
+		}
+	}
+
+	// several logs can be given separated by comma, they are parsed as a single log to aggregate a whole season
+	var logFiles []io.Reader
+	for _, path := range strings.Split(logPath, ",") {
+		logFile, err := os.Open(path)
+		if err != nil {
+			log.Errorf(ctx, "Error to open file: %v", err)
+			return
+		}
+
+		defer logFile.Close()
+		logFiles = append(logFiles, logFile)
+	}
 
 	resultFile, err := os.Create("./result.json")
 	if err != nil {
@@ -48,10 +139,11 @@ func main() {
 
 	defer resultFile.Close()
 
-	rawLinesChan := make(chan string)
+	segmentsChan := make(chan dto.GameSegment)
+	reportsChan := make(chan dto.Report)
 	writerChan := make(chan dto.Report)
 
-	svc, err := service.New(log)
+	svc, err := service.New(log, cfg)
 	if err != nil {
 		log.Errorf(ctx, "Error getting NewAuthToken: %v", err)
 		return
@@ -65,15 +157,141 @@ func main() {
 		writer.NewWriter(resultFile, log).StartWriting(ctx, writerChan)
 	}()
 
+	// keep the fields of every report used by the aggregations across all matches, the rest is dropped once the report is written
+	reports := make(dto.QuakeDataReport)
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		svc.QuakeService.StartExtractingData(ctx, rawLinesChan, writerChan)
+		defer close(writerChan)
+		for report := range reportsChan {
+			reports[report.GameName] = report.Aggregated()
+			if cfg.Timeline {
+				writeTimeline(ctx, log, report)
+				report.Timeline = nil
+			}
+			writerChan <- report
+		}
 	}()
 
-	scripts.NewQuakeLogParser(log).ReadLinesFromQuakeLog(ctx, logFile, rawLinesChan)
+	// the games are parsed concurrently and their reports come back in game order.
+	// In strict mode the extraction stops on the first malformed line, and the cancel stops the reading of the logs
+	var extractErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if extractErr = svc.QuakeService.StartExtractingGames(ctx, segmentsChan, reportsChan); extractErr != nil {
+			cancel()
+		}
+	}()
+
+	// a log that can not be read until the end stops the extraction, as its reports would be incomplete
+	readErr := scripts.NewQuakeLogParser(log).ReadGamesFromQuakeLogs(ctx, logFiles, segmentsChan)
+	if readErr != nil {
+		cancel()
+	}
 
 	wg.Wait()
 
+	writeFile(ctx, log, "./diagnostics.json", svc.QuakeService.Diagnostics())
+	if extractErr != nil {
+		log.Errorf(ctx, "Aborted by strict mode: %v", extractErr)
+		os.Exit(1)
+	}
+
+	if readErr != nil {
+		log.Errorf(ctx, "Error to read the logs: %v", readErr)
+		os.Exit(1)
+	}
+
+	headToHead := reports.HeadToHead()
+	writeFile(ctx, log, "./head_to_head.json", headToHead)
+
+	writeFile(ctx, log, "./players.json", reports.Careers())
+
+	ratingState := dto.NewRatingState()
+	if ratingsPath != "" {
+		if err := readFile(ratingsPath, ratingState); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			log.Errorf(ctx, "Error to read the rating table: %v", err)
+			return
+		}
+	}
+
+	ratingHistory := reports.UpdateRatings(ratingState)
+	writeFile(ctx, log, "./ratings.json", dto.RatingsReport{Ratings: ratingState.Ratings, History: ratingHistory})
+	if ratingsPath != "" {
+		writeFile(ctx, log, ratingsPath, ratingState)
+	}
+
+	if len(cfg.Awards) > 0 {
+		writeFile(ctx, log, "./awards.json", reports.EvaluateAwards(cfg.Awards))
+	}
+
+	if len(cfg.WordFilter) > 0 {
+		writeFile(ctx, log, "./moderation.json", reports.Moderation())
+	}
+
+	if headToHeadPlayer != "" {
+		printRivals(headToHeadPlayer, headToHead.Rivals(headToHeadPlayer))
+	}
+
 	log.Infof(ctx, "Execution time: %v", time.Since(start))
 }
+
+// writeFile creates the file on the given path and writes the data as JSON.
+func writeFile(ctx context.Context, log utilslogger.Logger, path string, data any) {
+	file, err := os.Create(path)
+	if err != nil {
+		log.Errorf(ctx, "Error to create file: %v", err)
+		return
+	}
+
+	defer file.Close()
+
+	writer.NewWriter(file, log).Write(ctx, data)
+}
+
+// writeTimeline writes the timeline of the match to the timeline directory, as a JSON array or as NDJSON.
+func writeTimeline(ctx context.Context, log utilslogger.Logger, report dto.Report) {
+	path := filepath.Join(timelineDir, report.GameName+"."+timelineFormat)
+	if timelineFormat == "json" {
+		writeFile(ctx, log, path, report.Timeline)
+		return
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		log.Errorf(ctx, "Error to create file: %v", err)
+		return
+	}
+
+	defer file.Close()
+
+	lines := make([]any, 0, len(report.Timeline))
+	for _, event := range report.Timeline {
+		lines = append(lines, event)
+	}
+	writer.NewWriter(file, log).WriteLines(ctx, lines)
+}
+
+// readFile reads the JSON file on the given path into the data.
+func readFile(path string, data any) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(content, data)
+}
+
+// printRivals prints the head-to-head kills of the player against every opponent.
+func printRivals(player string, rivals []dto.Rivalry) {
+	fmt.Printf("Head-to-head for %s across all matches:\n", player)
+	if len(rivals) == 0 {
+		fmt.Println("  no kills found")
+		return
+	}
+
+	for _, rival := range rivals {
+		fmt.Printf("  vs %-20s killed %3d  killed by %3d\n", rival.Opponent, rival.Kills, rival.Deaths)
+	}
+}
@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+)
+
+// AwardMetric represents the player stat compared by an award rule.
+type AwardMetric string
+
+const (
+	AwardMetricKills         AwardMetric = "kills"           // Kills of the player, minus the <world> deaths.
+	AwardMetricFrags         AwardMetric = "frags"           // Kills of other players, without team kills.
+	AwardMetricDeaths        AwardMetric = "deaths"          // All the deaths of the player.
+	AwardMetricSuicides      AwardMetric = "suicides"        // Kills by the player on themself.
+	AwardMetricWorldDeaths   AwardMetric = "world_deaths"    // Deaths caused by <world>.
+	AwardMetricTeamKills     AwardMetric = "team_kills"      // Teammates killed by the player.
+	AwardMetricNetScore      AwardMetric = "net_score"       // Frags minus suicides, <world> deaths and team kills.
+	AwardMetricKDRatio       AwardMetric = "kd_ratio"        // Frags by deaths.
+	AwardMetricLongestStreak AwardMetric = "longest_streak"  // Most kills without dying.
+	AwardMetricLongestLife   AwardMetric = "longest_life"    // Longest time alive, in seconds.
+	AwardMetricMultiKills    AwardMetric = "multi_kills"     // Number of multi-kills of any size.
+	AwardMetricKillsByMeans  AwardMetric = "kills_by_means"  // Kills made with the means of the rule.
+	AwardMetricDeathsByMeans AwardMetric = "deaths_by_means" // Deaths caused by the means of the rule.
+	AwardMetricItems         AwardMetric = "items"           // Item pickups, of the class of the rule if it has one.
+	AwardMetricCaptures      AwardMetric = "captures"        // Enemy flags captured.
+)
+
+var awardMetrics = map[AwardMetric]bool{
+	AwardMetricKills:         true,
+	AwardMetricFrags:         true,
+	AwardMetricDeaths:        true,
+	AwardMetricSuicides:      true,
+	AwardMetricWorldDeaths:   true,
+	AwardMetricTeamKills:     true,
+	AwardMetricNetScore:      true,
+	AwardMetricKDRatio:       true,
+	AwardMetricLongestStreak: true,
+	AwardMetricLongestLife:   true,
+	AwardMetricMultiKills:    true,
+	AwardMetricKillsByMeans:  true,
+	AwardMetricDeathsByMeans: true,
+	AwardMetricItems:         true,
+	AwardMetricCaptures:      true,
+}
+
+// IsMax returns true if the metric is the best value of each match instead of a counter, so it is not summed across matches.
+func (m AwardMetric) IsMax() bool {
+	return m == AwardMetricLongestStreak || m == AwardMetricLongestLife
+}
+
+// AwardRule represents an award given at the end of a match, or of all the matches, to the players with the best value of a metric.
+type AwardRule struct {
+	Name   string      `yaml:"name"`             // Name is the name of the award, like "Rocket man".
+	Metric AwardMetric `yaml:"metric"`           // Metric is the player stat compared by the award.
+	Means  []string    `yaml:"means,omitempty"`  // Means are the MOD_* means summed by the kills_by_means and deaths_by_means metrics.
+	Class  ItemClass   `yaml:"class,omitempty"`  // Class is the item class counted by the items metric, all the classes if empty.
+	Lowest bool        `yaml:"lowest,omitempty"` // Lowest gives the award to the lowest value instead of the highest.
+}
+
+// Validate returns an error if the rule has no name, an unknown metric or misses the means of a by means metric.
+func (r AwardRule) Validate() error {
+	if r.Name == "" {
+		return errors.New("award without name")
+	}
+
+	if !awardMetrics[r.Metric] {
+		return fmt.Errorf("award %q has an unknown metric %q", r.Name, r.Metric)
+	}
+
+	if (r.Metric == AwardMetricKillsByMeans || r.Metric == AwardMetricDeathsByMeans) && len(r.Means) == 0 {
+		return fmt.Errorf("award %q needs the means of the %s metric", r.Name, r.Metric)
+	}
+
+	return nil
+}
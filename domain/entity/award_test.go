@@ -0,0 +1,43 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
+func TestAwardRule_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    entity.AwardRule
+		wantErr bool
+	}{
+		{
+			name: "Should accept a valid rule",
+			rule: entity.AwardRule{Name: "Rocket man", Metric: entity.AwardMetricKillsByMeans, Means: []string{"MOD_ROCKET"}},
+		},
+		{
+			name:    "Should return error if the rule has no name",
+			rule:    entity.AwardRule{Metric: entity.AwardMetricFrags},
+			wantErr: true,
+		},
+		{
+			name:    "Should return error if the metric is unknown",
+			rule:    entity.AwardRule{Name: "Camper", Metric: "camping"},
+			wantErr: true,
+		},
+		{
+			name:    "Should return error if a by means metric has no means",
+			rule:    entity.AwardRule{Name: "Gravity victim", Metric: entity.AwardMetricDeathsByMeans},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rule.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
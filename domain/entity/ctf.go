@@ -0,0 +1,47 @@
+package entity
+
+// CTFAction represents the action of a capture the flag event.
+type CTFAction int
+
+const (
+	CTFActionPickup  CTFAction = iota // The player picked up the enemy flag.
+	CTFActionCapture                  // The player captured the enemy flag.
+	CTFActionReturn                   // The player returned the own team flag.
+	CTFActionDefend                   // The player killed the enemy flag carrier.
+)
+
+var ctfActionNames = map[CTFAction]string{
+	CTFActionPickup:  "pickup",
+	CTFActionCapture: "capture",
+	CTFActionReturn:  "return",
+	CTFActionDefend:  "defend",
+}
+
+// String returns the name of the action, like "pickup" or "capture".
+func (a CTFAction) String() string {
+	if name, ok := ctfActionNames[a]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+// CTFEvent represents a capture the flag line, printed by ioq3 and OSP servers as "CTF: <client> <team> <action>: <text>".
+type CTFEvent struct {
+	Time     GameTime  // Time of the event.
+	ClientID int       // ID of the client that performed the action.
+	Team     Team      // Team of the client.
+	Action   CTFAction // Action performed by the client.
+}
+
+// FlagTeam returns the team of the flag if the item is a capture the flag flag, like "team_CTF_redflag".
+func FlagTeam(item string) (Team, bool) {
+	switch item {
+	case "team_CTF_redflag":
+		return TeamRed, true
+	case "team_CTF_blueflag":
+		return TeamBlue, true
+	}
+
+	return TeamFree, false
+}
@@ -0,0 +1,45 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
+func TestFlagTeam(t *testing.T) {
+	tests := []struct {
+		name     string
+		item     string
+		want     entity.Team
+		wantFlag bool
+	}{
+		{name: "Should return red for the red flag", item: "team_CTF_redflag", want: entity.TeamRed, wantFlag: true},
+		{name: "Should return blue for the blue flag", item: "team_CTF_blueflag", want: entity.TeamBlue, wantFlag: true},
+		{name: "Should return false for other items", item: "weapon_rocketlauncher", want: entity.TeamFree},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotFlag := entity.FlagTeam(tt.item)
+			if got != tt.want || gotFlag != tt.wantFlag {
+				t.Errorf("FlagTeam() got = %v %v, want %v %v", got, gotFlag, tt.want, tt.wantFlag)
+			}
+		})
+	}
+}
+
+func TestCTFAction_String(t *testing.T) {
+	actions := map[entity.CTFAction]string{
+		entity.CTFActionPickup:  "pickup",
+		entity.CTFActionCapture: "capture",
+		entity.CTFActionReturn:  "return",
+		entity.CTFActionDefend:  "defend",
+		entity.CTFAction(9):     "unknown",
+	}
+
+	for action, want := range actions {
+		if got := action.String(); got != want {
+			t.Errorf("String() got = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package entity
+
+import "strings"
+
+// EndReason represents how a match ended.
+type EndReason string
+
+const (
+	EndReasonFragLimit    EndReason = "fraglimit"    // The frag limit was hit.
+	EndReasonTimeLimit    EndReason = "timelimit"    // The time limit was hit.
+	EndReasonCaptureLimit EndReason = "capturelimit" // The capture limit was hit.
+	EndReasonExit         EndReason = "exit"         // The match exited with a reason that is not recognised.
+	EndReasonShutdown     EndReason = "shutdown"     // The server shut down the match without an exit.
+	EndReasonTruncated    EndReason = "truncated"    // The match has no end, a new match or the end of the log came first.
+)
+
+// IsComplete returns true if the match was played until the server exited it.
+func (r EndReason) IsComplete() bool {
+	switch r {
+	case EndReasonFragLimit, EndReasonTimeLimit, EndReasonCaptureLimit, EndReasonExit:
+		return true
+	}
+
+	return false
+}
+
+// EndReason returns the end reason of the match based on the text of the Exit line.
+func (e ExitEvent) EndReason() EndReason {
+	switch strings.ToLower(e.Reason) {
+	case "fraglimit hit.":
+		return EndReasonFragLimit
+	case "timelimit hit.":
+		return EndReasonTimeLimit
+	case "capturelimit hit.":
+		return EndReasonCaptureLimit
+	}
+
+	return EndReasonExit
+}
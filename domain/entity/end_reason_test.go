@@ -0,0 +1,62 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
+func TestExitEvent_EndReason(t *testing.T) {
+	tests := []struct {
+		name         string
+		event        entity.ExitEvent
+		want         entity.EndReason
+		wantComplete bool
+	}{
+		{
+			name:         "Should return fraglimit",
+			event:        entity.ExitEvent{Reason: "Fraglimit hit."},
+			want:         entity.EndReasonFragLimit,
+			wantComplete: true,
+		},
+		{
+			name:         "Should return timelimit",
+			event:        entity.ExitEvent{Reason: "Timelimit hit."},
+			want:         entity.EndReasonTimeLimit,
+			wantComplete: true,
+		},
+		{
+			name:         "Should return capturelimit",
+			event:        entity.ExitEvent{Reason: "Capturelimit hit."},
+			want:         entity.EndReasonCaptureLimit,
+			wantComplete: true,
+		},
+		{
+			name:         "Should return exit for an unknown reason",
+			event:        entity.ExitEvent{Reason: "Something else."},
+			want:         entity.EndReasonExit,
+			wantComplete: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.event.EndReason()
+			if got != tt.want {
+				t.Errorf("EndReason() got = %v, want %v", got, tt.want)
+			}
+
+			if got.IsComplete() != tt.wantComplete {
+				t.Errorf("IsComplete() got = %v, want %v", got.IsComplete(), tt.wantComplete)
+			}
+		})
+	}
+}
+
+func TestEndReason_IsComplete(t *testing.T) {
+	for _, reason := range []entity.EndReason{entity.EndReasonShutdown, entity.EndReasonTruncated, ""} {
+		if reason.IsComplete() {
+			t.Errorf("IsComplete() got = true for %q, want false", reason)
+		}
+	}
+}
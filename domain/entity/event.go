@@ -0,0 +1,232 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrUnknownEvent is returned by ParseLine when the line has a valid timestamp but its kind is not recognised.
+var ErrUnknownEvent = errors.New("unknown event")
+
+// GameTime represents the mm:ss timestamp that prefixes every line of the game log.
+type GameTime struct {
+	Minutes int // Minutes since the server started, it can be greater than 59.
+	Seconds int // Seconds of the current minute.
+}
+
+// String returns the timestamp in the same format used by the game log.
+func (t GameTime) String() string {
+	return fmt.Sprintf("%d:%02d", t.Minutes, t.Seconds)
+}
+
+// Duration returns the time elapsed since the server started.
+func (t GameTime) Duration() time.Duration {
+	return time.Duration(t.Minutes)*time.Minute + time.Duration(t.Seconds)*time.Second
+}
+
+// Elapsed returns the time elapsed since the start of the match, as the clock of the log is relative to the server start.
+// If the timestamp is before the start, the server clock was restarted and the timestamp itself is the elapsed time.
+func (t GameTime) Elapsed(start GameTime) time.Duration {
+	if t.Duration() < start.Duration() {
+		return t.Duration()
+	}
+
+	return t.Duration() - start.Duration()
+}
+
+// Add returns the timestamp after the duration, truncated to the second.
+func (t GameTime) Add(d time.Duration) GameTime {
+	total := t.Duration() + d
+	return GameTime{Minutes: int(total / time.Minute), Seconds: int(total % time.Minute / time.Second)}
+}
+
+// Event represents a single parsed line of the game log.
+// The set of events is sealed, only the types declared in this package implement it.
+type Event interface {
+	// Timestamp returns the game time of the line that originated the event.
+	Timestamp() GameTime
+	isEvent()
+}
+
+// InitGameEvent represents the start of a new match.
+type InitGameEvent struct {
+	Time   GameTime    // Time of the event.
+	Config MatchConfig // Config is the decoded server settings of the match.
+}
+
+// ExitEvent represents the end of a match, when a limit is hit.
+type ExitEvent struct {
+	Time   GameTime // Time of the event.
+	Reason string   // Reason is the text printed by the server, like "Fraglimit hit.".
+}
+
+// ShutdownGameEvent represents the server shutting down the current match.
+type ShutdownGameEvent struct {
+	Time GameTime // Time of the event.
+}
+
+// ClientConnectEvent represents a client connecting to the server.
+type ClientConnectEvent struct {
+	Time     GameTime // Time of the event.
+	ClientID int      // ID of the client slot.
+}
+
+// ClientUserinfoChangedEvent represents a change on the client information, like the name.
+type ClientUserinfoChangedEvent struct {
+	Time     GameTime // Time of the event.
+	ClientID int      // ID of the client slot.
+	Name     string   // Name of the player.
+	Team     Team     // Team of the player.
+}
+
+// ClientBeginEvent represents a client entering the game after connecting.
+type ClientBeginEvent struct {
+	Time     GameTime // Time of the event.
+	ClientID int      // ID of the client slot.
+}
+
+// ClientDisconnectEvent represents a client leaving the server.
+type ClientDisconnectEvent struct {
+	Time     GameTime // Time of the event.
+	ClientID int      // ID of the client slot.
+}
+
+// ItemEvent represents an item picked up by a player.
+type ItemEvent struct {
+	Time     GameTime // Time of the event.
+	ClientID int      // ID of the client that picked up the item.
+	Item     string   // Item is the item class name, like "weapon_rocketlauncher".
+}
+
+// ScoreEvent represents a line of the final scoreboard printed by the server.
+type ScoreEvent struct {
+	Time     GameTime // Time of the event.
+	Score    int      // Score is the frag count of the player.
+	Ping     int      // Ping of the player.
+	ClientID int      // ID of the client slot.
+	Name     string   // Name of the player.
+}
+
+// SayEvent represents a chat message.
+type SayEvent struct {
+	Time     GameTime // Time of the event.
+	Name     string   // Name of the player who sent the message.
+	Message  string   // Message sent by the player.
+	TeamOnly bool     // TeamOnly is true for the messages sent only to the team, on sayteam lines.
+}
+
+// TeamScoreEvent represents the final red and blue team scores of a team match.
+type TeamScoreEvent struct {
+	Time GameTime // Time of the event.
+	Red  int      // Red team score.
+	Blue int      // Blue team score.
+}
+
+// SeparatorEvent represents the dashed lines printed around each match.
+type SeparatorEvent struct {
+	Time GameTime // Time of the event.
+}
+
+func (e InitGameEvent) Timestamp() GameTime              { return e.Time }
+func (e ExitEvent) Timestamp() GameTime                  { return e.Time }
+func (e ShutdownGameEvent) Timestamp() GameTime          { return e.Time }
+func (e ClientConnectEvent) Timestamp() GameTime         { return e.Time }
+func (e ClientUserinfoChangedEvent) Timestamp() GameTime { return e.Time }
+func (e ClientBeginEvent) Timestamp() GameTime           { return e.Time }
+func (e ClientDisconnectEvent) Timestamp() GameTime      { return e.Time }
+func (e KillEvent) Timestamp() GameTime                  { return e.Time }
+func (e ItemEvent) Timestamp() GameTime                  { return e.Time }
+func (e ScoreEvent) Timestamp() GameTime                 { return e.Time }
+func (e SayEvent) Timestamp() GameTime                   { return e.Time }
+func (e TeamScoreEvent) Timestamp() GameTime             { return e.Time }
+func (e SeparatorEvent) Timestamp() GameTime             { return e.Time }
+func (e CTFEvent) Timestamp() GameTime                   { return e.Time }
+
+func (InitGameEvent) isEvent()              {}
+func (ExitEvent) isEvent()                  {}
+func (ShutdownGameEvent) isEvent()          {}
+func (ClientConnectEvent) isEvent()         {}
+func (ClientUserinfoChangedEvent) isEvent() {}
+func (ClientBeginEvent) isEvent()           {}
+func (ClientDisconnectEvent) isEvent()      {}
+func (KillEvent) isEvent()                  {}
+func (ItemEvent) isEvent()                  {}
+func (ScoreEvent) isEvent()                 {}
+func (SayEvent) isEvent()                   {}
+func (TeamScoreEvent) isEvent()             {}
+func (SeparatorEvent) isEvent()             {}
+func (CTFEvent) isEvent()                   {}
+
+// ParseLine parses a line of the game log into its typed event.
+// It returns ErrUnknownEvent if the line kind is not recognised, or an error if the line is malformed.
+// The line is split by Tokenize, so the only allocations are the returned event and the settings of the InitGame lines.
+func ParseLine(line string) (Event, error) {
+	token, ok := Tokenize(line)
+	if !ok {
+		return nil, fmt.Errorf("invalid timestamp in line: %s", line)
+	}
+
+	return token.Event()
+}
+
+// Event returns the typed event of the token, like ParseLine, for a line that was already split by Tokenize.
+func (t Token) Event() (Event, error) {
+	switch t.Kind {
+	case KindInitGame:
+		return InitGameEvent{Time: t.Time, Config: ParseMatchConfig(strings.TrimSpace(t.Args))}, nil
+	case KindExit:
+		return ExitEvent{Time: t.Time, Reason: strings.TrimSpace(t.Args)}, nil
+	case KindShutdownGame:
+		return ShutdownGameEvent{Time: t.Time}, nil
+	case KindClientConnect:
+		id, err := t.ClientID()
+		if err != nil {
+			return nil, err
+		}
+
+		return ClientConnectEvent{Time: t.Time, ClientID: id}, nil
+	case KindClientUserinfoChanged:
+		return eventOf(t.UserinfoChanged())
+	case KindClientBegin:
+		id, err := t.ClientID()
+		if err != nil {
+			return nil, err
+		}
+
+		return ClientBeginEvent{Time: t.Time, ClientID: id}, nil
+	case KindClientDisconnect:
+		id, err := t.ClientID()
+		if err != nil {
+			return nil, err
+		}
+
+		return ClientDisconnectEvent{Time: t.Time, ClientID: id}, nil
+	case KindKill:
+		return eventOf(t.Kill())
+	case KindItem:
+		return eventOf(t.Item())
+	case KindScore:
+		return eventOf(t.Score())
+	case KindSay, KindSayTeam:
+		return eventOf(t.Say())
+	case KindTeamScore:
+		return eventOf(t.TeamScore())
+	case KindCTF:
+		return eventOf(t.CTF())
+	case KindSeparator:
+		return SeparatorEvent{Time: t.Time}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, t.Line)
+}
+
+// eventOf returns the event extracted from a token, or a nil event if the extraction failed.
+func eventOf[T Event](event T, err error) (Event, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
@@ -0,0 +1,326 @@
+package entity_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
+func TestParseLine(t *testing.T) {
+	type args struct {
+		line string
+	}
+
+	tests := []struct {
+		name        string
+		args        args
+		want        entity.Event
+		wantErr     bool
+		wantUnknown bool
+	}{
+		{
+			name: "Should parse an InitGame line",
+			args: args{
+				line: `  0:00 InitGame: \sv_hostname\Code Miner Server\g_gametype\0\mapname\q3dm17`,
+			},
+			want: entity.InitGameEvent{
+				Time: entity.GameTime{Minutes: 0, Seconds: 0},
+				Config: entity.MatchConfig{
+					MapName:  "q3dm17",
+					GameType: entity.GameTypeFFA,
+					Hostname: "Code Miner Server",
+					Settings: map[string]string{
+						"sv_hostname": "Code Miner Server",
+						"g_gametype":  "0",
+						"mapname":     "q3dm17",
+					},
+				},
+			},
+		},
+		{
+			name: "Should parse an Exit line",
+			args: args{
+				line: ` 15:00 Exit: Timelimit hit.`,
+			},
+			want: entity.ExitEvent{
+				Time:   entity.GameTime{Minutes: 15, Seconds: 0},
+				Reason: "Timelimit hit.",
+			},
+		},
+		{
+			name: "Should parse a ShutdownGame line",
+			args: args{
+				line: ` 20:37 ShutdownGame:`,
+			},
+			want: entity.ShutdownGameEvent{Time: entity.GameTime{Minutes: 20, Seconds: 37}},
+		},
+		{
+			name: "Should parse a ClientConnect line",
+			args: args{
+				line: ` 20:34 ClientConnect: 2`,
+			},
+			want: entity.ClientConnectEvent{Time: entity.GameTime{Minutes: 20, Seconds: 34}, ClientID: 2},
+		},
+		{
+			name: "Should parse a ClientUserinfoChanged line",
+			args: args{
+				line: ` 20:34 ClientUserinfoChanged: 2 n\Isgalamido\t\0\model\xian/default\hmodel\xian/default`,
+			},
+			want: entity.ClientUserinfoChangedEvent{
+				Time:     entity.GameTime{Minutes: 20, Seconds: 34},
+				ClientID: 2,
+				Name:     "Isgalamido",
+			},
+		},
+		{
+			name: "Should parse the team of a ClientUserinfoChanged line",
+			args: args{
+				line: ` 10:28 ClientUserinfoChanged: 2 n\Isgalamido\t\1\model\uriel/zael\hmodel\uriel/zael\g_redteam\\g_blueteam\\c1\5\c2\5\hc\100\w\0\l\0\tt\0\tl\0`,
+			},
+			want: entity.ClientUserinfoChangedEvent{
+				Time:     entity.GameTime{Minutes: 10, Seconds: 28},
+				ClientID: 2,
+				Name:     "Isgalamido",
+				Team:     entity.TeamRed,
+			},
+		},
+		{
+			name: "Should parse a ClientBegin line",
+			args: args{
+				line: ` 20:37 ClientBegin: 2`,
+			},
+			want: entity.ClientBeginEvent{Time: entity.GameTime{Minutes: 20, Seconds: 37}, ClientID: 2},
+		},
+		{
+			name: "Should parse a ClientDisconnect line",
+			args: args{
+				line: ` 21:10 ClientDisconnect: 2`,
+			},
+			want: entity.ClientDisconnectEvent{Time: entity.GameTime{Minutes: 21, Seconds: 10}, ClientID: 2},
+		},
+		{
+			name: "Should parse a Kill line",
+			args: args{
+				line: ` 22:06 Kill: 2 3 7: Isgalamido killed Mocinha by MOD_ROCKET_SPLASH`,
+			},
+			want: entity.KillEvent{
+				Time:       entity.GameTime{Minutes: 22, Seconds: 6},
+				KillerID:   2,
+				KilledID:   3,
+				KillerName: "Isgalamido",
+				KilledName: "Mocinha",
+				DeathCause: "MOD_ROCKET_SPLASH",
+				MeanID:     7,
+			},
+		},
+		{
+			name: "Should parse an Item line",
+			args: args{
+				line: ` 20:40 Item: 2 weapon_rocketlauncher`,
+			},
+			want: entity.ItemEvent{
+				Time:     entity.GameTime{Minutes: 20, Seconds: 40},
+				ClientID: 2,
+				Item:     "weapon_rocketlauncher",
+			},
+		},
+		{
+			name: "Should parse a score line",
+			args: args{
+				line: ` 11:15 score: -3  ping: 15  client: 6 Assasinu Credi`,
+			},
+			want: entity.ScoreEvent{
+				Time:     entity.GameTime{Minutes: 11, Seconds: 15},
+				Score:    -3,
+				Ping:     15,
+				ClientID: 6,
+				Name:     "Assasinu Credi",
+			},
+		},
+		{
+			name: "Should parse a say line",
+			args: args{
+				line: `981:21 say: Oootsimo: team red`,
+			},
+			want: entity.SayEvent{
+				Time:    entity.GameTime{Minutes: 981, Seconds: 21},
+				Name:    "Oootsimo",
+				Message: "team red",
+			},
+		},
+		{
+			name: "Should parse a sayteam line",
+			args: args{
+				line: ` 12:02 sayteam: Zeh: cover the flag`,
+			},
+			want: entity.SayEvent{
+				Time:     entity.GameTime{Minutes: 12, Seconds: 2},
+				Name:     "Zeh",
+				Message:  "cover the flag",
+				TeamOnly: true,
+			},
+		},
+		{
+			name: "Should parse a team score line",
+			args: args{
+				line: ` 10:12 red:8  blue:6`,
+			},
+			want: entity.TeamScoreEvent{Time: entity.GameTime{Minutes: 10, Seconds: 12}, Red: 8, Blue: 6},
+		},
+		{
+			name: "Should parse a CTF line",
+			args: args{
+				line: ` 10:09 CTF: 7 2 1: Assasinu Credi captured the RED flag!`,
+			},
+			want: entity.CTFEvent{
+				Time:     entity.GameTime{Minutes: 10, Seconds: 9},
+				ClientID: 7,
+				Team:     entity.TeamBlue,
+				Action:   entity.CTFActionCapture,
+			},
+		},
+		{
+			name: "Should parse a separator line",
+			args: args{
+				line: `  0:00 ------------------------------------------------------------`,
+			},
+			want: entity.SeparatorEvent{Time: entity.GameTime{Minutes: 0, Seconds: 0}},
+		},
+		{
+			name: "Should return error if the line has no timestamp",
+			args: args{
+				line: ` Kill: 1022 2 19: <world> killed Isgalamido by MOD_TRIGGER_HURT`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error if the line is malformed",
+			args: args{
+				line: ` 20:40 Item: a weapon_rocketlauncher`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error if the client id is not a number",
+			args: args{
+				line: ` 20:34 ClientConnect: a`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return ErrUnknownEvent if the kind is not recognised",
+			args: args{
+				line: ` 20:34 Something: 2`,
+			},
+			wantErr:     true,
+			wantUnknown: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := entity.ParseLine(tt.args.line)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseLine() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if errors.Is(err, entity.ErrUnknownEvent) != tt.wantUnknown {
+				t.Errorf("ParseLine() error = %v, wantUnknown %v", err, tt.wantUnknown)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLine() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGameTime_Elapsed(t *testing.T) {
+	type args struct {
+		start entity.GameTime
+	}
+
+	tests := []struct {
+		name         string
+		time         entity.GameTime
+		args         args
+		wantDuration time.Duration
+		want         time.Duration
+		wantString   string
+	}{
+		{
+			name:         "Should return the elapsed time since the start of the match",
+			time:         entity.GameTime{Minutes: 26, Seconds: 9},
+			args:         args{start: entity.GameTime{Minutes: 20, Seconds: 37}},
+			wantDuration: 26*time.Minute + 9*time.Second,
+			want:         5*time.Minute + 32*time.Second,
+			wantString:   "26:09",
+		},
+		{
+			name:         "Should handle minutes past 59",
+			time:         entity.GameTime{Minutes: 981, Seconds: 27},
+			args:         args{start: entity.GameTime{Minutes: 981, Seconds: 0}},
+			wantDuration: 981*time.Minute + 27*time.Second,
+			want:         27 * time.Second,
+			wantString:   "981:27",
+		},
+		{
+			name:         "Should return the timestamp itself if the server clock was restarted",
+			time:         entity.GameTime{Minutes: 0, Seconds: 25},
+			args:         args{start: entity.GameTime{Minutes: 26, Seconds: 9}},
+			wantDuration: 25 * time.Second,
+			want:         25 * time.Second,
+			wantString:   "0:25",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.time.Duration(); got != tt.wantDuration {
+				t.Errorf("Duration() got = %v, want %v", got, tt.wantDuration)
+			}
+
+			if got := tt.time.Elapsed(tt.args.start); got != tt.want {
+				t.Errorf("Elapsed() got = %v, want %v", got, tt.want)
+			}
+
+			if got := tt.time.String(); got != tt.wantString {
+				t.Errorf("String() got = %v, want %v", got, tt.wantString)
+			}
+		})
+	}
+}
+
+func TestGameTime_Add(t *testing.T) {
+	tests := []struct {
+		name string
+		time entity.GameTime
+		d    time.Duration
+		want entity.GameTime
+	}{
+		{
+			name: "Should carry the seconds to the minutes",
+			time: entity.GameTime{Minutes: 20, Seconds: 37},
+			d:    90 * time.Second,
+			want: entity.GameTime{Minutes: 22, Seconds: 7},
+		},
+		{
+			name: "Should truncate to the second",
+			time: entity.GameTime{Minutes: 0, Seconds: 0},
+			d:    1500 * time.Millisecond,
+			want: entity.GameTime{Minutes: 0, Seconds: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.time.Add(tt.d); got != tt.want {
+				t.Errorf("Add() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
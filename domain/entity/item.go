@@ -0,0 +1,53 @@
+package entity
+
+import "strings"
+
+// ItemClass represents the class of an item that can be picked up.
+type ItemClass string
+
+const (
+	ItemClassWeapon   ItemClass = "weapon"   // Weapons, like "weapon_rocketlauncher".
+	ItemClassAmmo     ItemClass = "ammo"     // Ammo boxes, like "ammo_rockets".
+	ItemClassArmor    ItemClass = "armor"    // Armors and shards, like "item_armor_body".
+	ItemClassHealth   ItemClass = "health"   // Health boxes, like "item_health_mega".
+	ItemClassPowerup  ItemClass = "powerup"  // Powerups, like "item_quad" or "item_haste".
+	ItemClassHoldable ItemClass = "holdable" // Holdable items, like "holdable_teleporter".
+	ItemClassFlag     ItemClass = "flag"     // Capture the flag flags, like "team_CTF_redflag".
+	ItemClassOther    ItemClass = "other"    // Any item that is not recognised.
+)
+
+var powerups = map[string]bool{
+	"item_quad":   true,
+	"item_haste":  true,
+	"item_regen":  true,
+	"item_invis":  true,
+	"item_enviro": true,
+	"item_flight": true,
+}
+
+// ClassifyItem returns the class of the item based on its class name.
+func ClassifyItem(item string) ItemClass {
+	switch {
+	case strings.HasPrefix(item, "weapon_"):
+		return ItemClassWeapon
+	case strings.HasPrefix(item, "ammo_"):
+		return ItemClassAmmo
+	case strings.HasPrefix(item, "item_armor_"):
+		return ItemClassArmor
+	case strings.HasPrefix(item, "item_health"):
+		return ItemClassHealth
+	case powerups[item]:
+		return ItemClassPowerup
+	case strings.HasPrefix(item, "holdable_"):
+		return ItemClassHoldable
+	case strings.HasPrefix(item, "team_CTF_"):
+		return ItemClassFlag
+	}
+
+	return ItemClassOther
+}
+
+// Class returns the class of the picked up item.
+func (e ItemEvent) Class() ItemClass {
+	return ClassifyItem(e.Item)
+}
@@ -0,0 +1,37 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
+func TestClassifyItem(t *testing.T) {
+	tests := []struct {
+		item string
+		want entity.ItemClass
+	}{
+		{item: "weapon_rocketlauncher", want: entity.ItemClassWeapon},
+		{item: "ammo_rockets", want: entity.ItemClassAmmo},
+		{item: "item_armor_shard", want: entity.ItemClassArmor},
+		{item: "item_health", want: entity.ItemClassHealth},
+		{item: "item_health_mega", want: entity.ItemClassHealth},
+		{item: "item_quad", want: entity.ItemClassPowerup},
+		{item: "item_haste", want: entity.ItemClassPowerup},
+		{item: "holdable_medkit", want: entity.ItemClassHoldable},
+		{item: "team_CTF_blueflag", want: entity.ItemClassFlag},
+		{item: "item_unknown", want: entity.ItemClassOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.item, func(t *testing.T) {
+			if got := entity.ClassifyItem(tt.item); got != tt.want {
+				t.Errorf("ClassifyItem() got = %v, want %v", got, tt.want)
+			}
+
+			if got := (entity.ItemEvent{Item: tt.item}).Class(); got != tt.want {
+				t.Errorf("Class() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"strconv"
+	"strings"
+)
+
+// GameType represents the value of the g_gametype server setting.
+type GameType int
+
+const (
+	GameTypeFFA          GameType = iota // Free for all.
+	GameTypeTournament                   // One on one.
+	GameTypeSinglePlayer                 // Single player against bots.
+	GameTypeTDM                          // Team deathmatch.
+	GameTypeCTF                          // Capture the flag.
+	GameTypeOneFlagCTF                   // One flag capture the flag (Team Arena).
+	GameTypeObelisk                      // Overload (Team Arena).
+	GameTypeHarvester                    // Harvester (Team Arena).
+)
+
+var gameTypeNames = map[GameType]string{
+	GameTypeFFA:          "FFA",
+	GameTypeTournament:   "Tournament",
+	GameTypeSinglePlayer: "SinglePlayer",
+	GameTypeTDM:          "TDM",
+	GameTypeCTF:          "CTF",
+	GameTypeOneFlagCTF:   "OneFlagCTF",
+	GameTypeObelisk:      "Obelisk",
+	GameTypeHarvester:    "Harvester",
+}
+
+// String returns the name of the game type, like "FFA" or "CTF".
+func (g GameType) String() string {
+	if name, ok := gameTypeNames[g]; ok {
+		return name
+	}
+
+	return "Unknown(" + strconv.Itoa(int(g)) + ")"
+}
+
+// IsTeamGame returns true if the players are split in red and blue teams for the game type.
+func (g GameType) IsTeamGame() bool {
+	return g >= GameTypeTDM && g <= GameTypeHarvester
+}
+
+// MatchConfig represents the server settings printed on the InitGame line.
+type MatchConfig struct {
+	MapName      string            // MapName is the value of the mapname setting.
+	GameType     GameType          // GameType is the value of the g_gametype setting.
+	FragLimit    int               // FragLimit is the value of the fraglimit setting.
+	TimeLimit    int               // TimeLimit is the value of the timelimit setting, in minutes.
+	CaptureLimit int               // CaptureLimit is the value of the capturelimit setting.
+	Hostname     string            // Hostname is the value of the sv_hostname setting.
+	Version      string            // Version is the value of the version setting.
+	Protocol     int               // Protocol is the value of the protocol setting.
+	Settings     map[string]string // Settings holds every key and value of the line, including the ones above.
+}
+
+// ParseMatchConfig decodes the backslash-delimited server settings of an InitGame line.
+// Numeric settings with invalid values are left as zero, so a bad setting never drops the match.
+func ParseMatchConfig(settings string) MatchConfig {
+	config := MatchConfig{
+		Settings: make(map[string]string),
+	}
+
+	parts := strings.Split(strings.TrimPrefix(settings, `\`), `\`)
+	for i := 0; i+1 < len(parts); i += 2 {
+		config.Settings[parts[i]] = parts[i+1]
+	}
+
+	config.MapName = config.Settings["mapname"]
+	config.Hostname = config.Settings["sv_hostname"]
+	config.Version = config.Settings["version"]
+	config.GameType = GameType(getIntSetting(config.Settings, "g_gametype"))
+	config.FragLimit = getIntSetting(config.Settings, "fraglimit")
+	config.TimeLimit = getIntSetting(config.Settings, "timelimit")
+	config.CaptureLimit = getIntSetting(config.Settings, "capturelimit")
+	config.Protocol = getIntSetting(config.Settings, "protocol")
+
+	return config
+}
+
+// getIntSetting returns the numeric value of the setting key, or zero if it is missing or invalid.
+func getIntSetting(settings map[string]string, key string) int {
+	value, err := strconv.Atoi(settings[key])
+	if err != nil {
+		return 0
+	}
+
+	return value
+}
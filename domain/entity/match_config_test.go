@@ -0,0 +1,97 @@
+package entity_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
+func TestParseMatchConfig(t *testing.T) {
+	type args struct {
+		settings string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want entity.MatchConfig
+	}{
+		{
+			name: "Should parse the known settings",
+			args: args{
+				settings: `\sv_hostname\Code Miner Server\g_gametype\4\fraglimit\20\timelimit\15\capturelimit\8\version\ioq3 1.36 linux-x86_64 Apr 12 2009\protocol\68\mapname\Q3TOURNEY6_CTF`,
+			},
+			want: entity.MatchConfig{
+				MapName:      "Q3TOURNEY6_CTF",
+				GameType:     entity.GameTypeCTF,
+				FragLimit:    20,
+				TimeLimit:    15,
+				CaptureLimit: 8,
+				Hostname:     "Code Miner Server",
+				Version:      "ioq3 1.36 linux-x86_64 Apr 12 2009",
+				Protocol:     68,
+				Settings: map[string]string{
+					"sv_hostname":  "Code Miner Server",
+					"g_gametype":   "4",
+					"fraglimit":    "20",
+					"timelimit":    "15",
+					"capturelimit": "8",
+					"version":      "ioq3 1.36 linux-x86_64 Apr 12 2009",
+					"protocol":     "68",
+					"mapname":      "Q3TOURNEY6_CTF",
+				},
+			},
+		},
+		{
+			name: "Should keep zero values for missing or invalid settings",
+			args: args{
+				settings: `\fraglimit\abc\mapname\q3dm17\dangling`,
+			},
+			want: entity.MatchConfig{
+				MapName: "q3dm17",
+				Settings: map[string]string{
+					"fraglimit": "abc",
+					"mapname":   "q3dm17",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := entity.ParseMatchConfig(tt.args.settings)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseMatchConfig() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGameType_String(t *testing.T) {
+	tests := []struct {
+		name     string
+		gameType entity.GameType
+		want     string
+		wantTeam bool
+	}{
+		{name: "Should return FFA", gameType: entity.GameTypeFFA, want: "FFA"},
+		{name: "Should return Tournament", gameType: entity.GameTypeTournament, want: "Tournament"},
+		{name: "Should return TDM", gameType: entity.GameTypeTDM, want: "TDM", wantTeam: true},
+		{name: "Should return CTF", gameType: entity.GameTypeCTF, want: "CTF", wantTeam: true},
+		{name: "Should return Unknown for invalid values", gameType: entity.GameType(42), want: "Unknown(42)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.gameType.String(); got != tt.want {
+				t.Errorf("String() got = %v, want %v", got, tt.want)
+			}
+
+			if got := tt.gameType.IsTeamGame(); got != tt.wantTeam {
+				t.Errorf("IsTeamGame() got = %v, want %v", got, tt.wantTeam)
+			}
+		})
+	}
+}
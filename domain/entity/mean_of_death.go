@@ -0,0 +1,104 @@
+package entity
+
+import "fmt"
+
+// MeanCategory represents how a mean of death kills.
+type MeanCategory string
+
+const (
+	MeanCategoryDirect      MeanCategory = "direct"      // Direct hits of a weapon, or telefrags.
+	MeanCategorySplash      MeanCategory = "splash"      // Explosions near the player.
+	MeanCategoryEnvironment MeanCategory = "environment" // Map hazards and falls, the kills of <world>.
+	MeanCategorySuicide     MeanCategory = "suicide"     // The kill command of the player.
+)
+
+// MeanOfDeath represents a MOD_* value of the game, printed by the Kill lines as "<id>: ... by <name>".
+type MeanOfDeath struct {
+	ID       int          // ID is the number of the mean on the Kill lines.
+	Name     string       // Name is the MOD_* name of the mean.
+	Weapon   string       // Weapon is the weapon of the mean, or the kind of kill for the means without weapon, like "world".
+	Category MeanCategory // Category is how the mean kills.
+}
+
+// baseGrappleID is the ID of MOD_GRAPPLE on servers built without Team Arena, where the Team Arena means do not exist.
+const baseGrappleID = 23
+
+// meansOfDeath is the catalogue of the means of Quake III and Team Arena, in the order of the game, so the index is the ID.
+var meansOfDeath = []MeanOfDeath{
+	{ID: 0, Name: "MOD_UNKNOWN", Weapon: "unknown", Category: MeanCategoryEnvironment},
+	{ID: 1, Name: "MOD_SHOTGUN", Weapon: "shotgun", Category: MeanCategoryDirect},
+	{ID: 2, Name: "MOD_GAUNTLET", Weapon: "gauntlet", Category: MeanCategoryDirect},
+	{ID: 3, Name: "MOD_MACHINEGUN", Weapon: "machinegun", Category: MeanCategoryDirect},
+	{ID: 4, Name: "MOD_GRENADE", Weapon: "grenade_launcher", Category: MeanCategoryDirect},
+	{ID: 5, Name: "MOD_GRENADE_SPLASH", Weapon: "grenade_launcher", Category: MeanCategorySplash},
+	{ID: 6, Name: "MOD_ROCKET", Weapon: "rocket_launcher", Category: MeanCategoryDirect},
+	{ID: 7, Name: "MOD_ROCKET_SPLASH", Weapon: "rocket_launcher", Category: MeanCategorySplash},
+	{ID: 8, Name: "MOD_PLASMA", Weapon: "plasma_gun", Category: MeanCategoryDirect},
+	{ID: 9, Name: "MOD_PLASMA_SPLASH", Weapon: "plasma_gun", Category: MeanCategorySplash},
+	{ID: 10, Name: "MOD_RAILGUN", Weapon: "railgun", Category: MeanCategoryDirect},
+	{ID: 11, Name: "MOD_LIGHTNING", Weapon: "lightning_gun", Category: MeanCategoryDirect},
+	{ID: 12, Name: "MOD_BFG", Weapon: "bfg", Category: MeanCategoryDirect},
+	{ID: 13, Name: "MOD_BFG_SPLASH", Weapon: "bfg", Category: MeanCategorySplash},
+	{ID: 14, Name: "MOD_WATER", Weapon: "world", Category: MeanCategoryEnvironment},
+	{ID: 15, Name: "MOD_SLIME", Weapon: "world", Category: MeanCategoryEnvironment},
+	{ID: 16, Name: "MOD_LAVA", Weapon: "world", Category: MeanCategoryEnvironment},
+	{ID: 17, Name: "MOD_CRUSH", Weapon: "world", Category: MeanCategoryEnvironment},
+	{ID: 18, Name: "MOD_TELEFRAG", Weapon: "telefrag", Category: MeanCategoryDirect},
+	{ID: 19, Name: "MOD_FALLING", Weapon: "world", Category: MeanCategoryEnvironment},
+	{ID: 20, Name: "MOD_SUICIDE", Weapon: "suicide", Category: MeanCategorySuicide},
+	{ID: 21, Name: "MOD_TARGET_LASER", Weapon: "world", Category: MeanCategoryEnvironment},
+	{ID: 22, Name: "MOD_TRIGGER_HURT", Weapon: "world", Category: MeanCategoryEnvironment},
+	{ID: 23, Name: "MOD_NAIL", Weapon: "nailgun", Category: MeanCategoryDirect},
+	{ID: 24, Name: "MOD_CHAINGUN", Weapon: "chaingun", Category: MeanCategoryDirect},
+	{ID: 25, Name: "MOD_PROXIMITY_MINE", Weapon: "proximity_launcher", Category: MeanCategorySplash},
+	{ID: 26, Name: "MOD_KAMIKAZE", Weapon: "kamikaze", Category: MeanCategorySplash},
+	{ID: 27, Name: "MOD_JUICED", Weapon: "proximity_launcher", Category: MeanCategorySplash},
+	{ID: 28, Name: "MOD_GRAPPLE", Weapon: "grapple", Category: MeanCategoryDirect},
+}
+
+var meansByName = func() map[string]MeanOfDeath {
+	means := make(map[string]MeanOfDeath, len(meansOfDeath))
+	for _, mean := range meansOfDeath {
+		means[mean.Name] = mean
+	}
+	return means
+}()
+
+// MeanByID returns the mean of the ID, with the Team Arena numbering.
+func MeanByID(id int) (MeanOfDeath, bool) {
+	if id < 0 || id >= len(meansOfDeath) {
+		return MeanOfDeath{}, false
+	}
+
+	return meansOfDeath[id], true
+}
+
+// MeanByName returns the mean of the MOD_* name.
+func MeanByName(name string) (MeanOfDeath, bool) {
+	mean, ok := meansByName[name]
+	return mean, ok
+}
+
+// ValidateMean returns an error if the name is not a mean of the catalogue or if the ID of the line is not the ID of the name.
+// MOD_GRAPPLE is also accepted with its ID on servers built without Team Arena.
+func ValidateMean(id int, name string) error {
+	mean, ok := MeanByName(name)
+	if !ok {
+		return fmt.Errorf("unknown mean of death %s", name)
+	}
+
+	if id != mean.ID && !(name == "MOD_GRAPPLE" && id == baseGrappleID) {
+		return fmt.Errorf("mean of death %s has the id %d instead of %d", name, id, mean.ID)
+	}
+
+	return nil
+}
+
+// WeaponOf returns the weapon of the MOD_* name, or the name itself if it is not a mean of the catalogue.
+func WeaponOf(name string) string {
+	if mean, ok := MeanByName(name); ok {
+		return mean.Weapon
+	}
+
+	return name
+}
@@ -0,0 +1,79 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
+func TestMeanByID(t *testing.T) {
+	tests := []struct {
+		id     int
+		want   entity.MeanOfDeath
+		wantOk bool
+	}{
+		{id: 7, want: entity.MeanOfDeath{ID: 7, Name: "MOD_ROCKET_SPLASH", Weapon: "rocket_launcher", Category: entity.MeanCategorySplash}, wantOk: true},
+		{id: 22, want: entity.MeanOfDeath{ID: 22, Name: "MOD_TRIGGER_HURT", Weapon: "world", Category: entity.MeanCategoryEnvironment}, wantOk: true},
+		{id: 26, want: entity.MeanOfDeath{ID: 26, Name: "MOD_KAMIKAZE", Weapon: "kamikaze", Category: entity.MeanCategorySplash}, wantOk: true},
+		{id: 29},
+		{id: -1},
+	}
+
+	for _, tt := range tests {
+		got, ok := entity.MeanByID(tt.id)
+		if got != tt.want || ok != tt.wantOk {
+			t.Errorf("MeanByID(%d) got = %v, %v, want %v, %v", tt.id, got, ok, tt.want, tt.wantOk)
+		}
+
+		// the catalogue must map the name back to the same mean
+		if ok {
+			if byName, _ := entity.MeanByName(got.Name); byName != got {
+				t.Errorf("MeanByName(%s) got = %v, want %v", got.Name, byName, got)
+			}
+		}
+	}
+}
+
+func TestValidateMean(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      int
+		mean    string
+		wantErr bool
+	}{
+		{name: "Should accept the id of the mean", id: 10, mean: "MOD_RAILGUN"},
+		{name: "Should accept a Team Arena mean", id: 24, mean: "MOD_CHAINGUN"},
+		{name: "Should accept the grapple of the Team Arena servers", id: 28, mean: "MOD_GRAPPLE"},
+		{name: "Should accept the grapple of the servers without Team Arena", id: 23, mean: "MOD_GRAPPLE"},
+		{name: "Should return error if the id is not the id of the mean", id: 19, mean: "MOD_TRIGGER_HURT", wantErr: true},
+		{name: "Should return error if the mean is unknown", id: 30, mean: "MOD_CUSTOM", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := entity.ValidateMean(tt.id, tt.mean); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateMean() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWeaponOf(t *testing.T) {
+	tests := []struct {
+		mean string
+		want string
+	}{
+		{mean: "MOD_ROCKET", want: "rocket_launcher"},
+		{mean: "MOD_ROCKET_SPLASH", want: "rocket_launcher"},
+		{mean: "MOD_FALLING", want: "world"},
+		{mean: "MOD_CUSTOM", want: "MOD_CUSTOM"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mean, func(t *testing.T) {
+			if got := entity.WeaponOf(tt.mean); got != tt.want {
+				t.Errorf("WeaponOf() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
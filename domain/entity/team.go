@@ -0,0 +1,44 @@
+package entity
+
+// Team represents the value of the t setting of the client information.
+type Team int
+
+const (
+	TeamFree      Team = iota // Player without team, used on non team game types.
+	TeamRed                   // Red team.
+	TeamBlue                  // Blue team.
+	TeamSpectator             // Spectator.
+)
+
+var teamNames = map[Team]string{
+	TeamFree:      "free",
+	TeamRed:       "red",
+	TeamBlue:      "blue",
+	TeamSpectator: "spectator",
+}
+
+// String returns the name of the team, like "red" or "blue".
+func (t Team) String() string {
+	if name, ok := teamNames[t]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+// IsPlaying returns true if the team is the red or the blue team.
+func (t Team) IsPlaying() bool {
+	return t == TeamRed || t == TeamBlue
+}
+
+// Opponent returns the opposite playing team, or the team itself if it is not a playing team.
+func (t Team) Opponent() Team {
+	switch t {
+	case TeamRed:
+		return TeamBlue
+	case TeamBlue:
+		return TeamRed
+	}
+
+	return t
+}
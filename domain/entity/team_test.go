@@ -0,0 +1,53 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
+func TestTeam_String(t *testing.T) {
+	tests := []struct {
+		name        string
+		team        entity.Team
+		want        string
+		wantPlaying bool
+	}{
+		{name: "Should return free", team: entity.TeamFree, want: "free"},
+		{name: "Should return red", team: entity.TeamRed, want: "red", wantPlaying: true},
+		{name: "Should return blue", team: entity.TeamBlue, want: "blue", wantPlaying: true},
+		{name: "Should return spectator", team: entity.TeamSpectator, want: "spectator"},
+		{name: "Should return unknown for invalid values", team: entity.Team(9), want: "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.team.String(); got != tt.want {
+				t.Errorf("String() got = %v, want %v", got, tt.want)
+			}
+
+			if got := tt.team.IsPlaying(); got != tt.wantPlaying {
+				t.Errorf("IsPlaying() got = %v, want %v", got, tt.wantPlaying)
+			}
+		})
+	}
+}
+
+func TestTeam_Opponent(t *testing.T) {
+	tests := []struct {
+		team entity.Team
+		want entity.Team
+	}{
+		{team: entity.TeamRed, want: entity.TeamBlue},
+		{team: entity.TeamBlue, want: entity.TeamRed},
+		{team: entity.TeamSpectator, want: entity.TeamSpectator},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.team.String(), func(t *testing.T) {
+			if got := tt.team.Opponent(); got != tt.want {
+				t.Errorf("Opponent() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
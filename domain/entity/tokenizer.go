@@ -0,0 +1,368 @@
+package entity
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LineKind represents the kind of a line of the game log, given by the word that follows the timestamp.
+type LineKind int
+
+const (
+	KindUnknown               LineKind = iota // The kind is not recognised.
+	KindInitGame                              // InitGame: line.
+	KindExit                                  // Exit: line.
+	KindShutdownGame                          // ShutdownGame: line.
+	KindClientConnect                         // ClientConnect: line.
+	KindClientUserinfoChanged                 // ClientUserinfoChanged: line.
+	KindClientBegin                           // ClientBegin: line.
+	KindClientDisconnect                      // ClientDisconnect: line.
+	KindKill                                  // Kill: line.
+	KindItem                                  // Item: line.
+	KindScore                                 // score: line of the final scoreboard.
+	KindSay                                   // say: line.
+	KindSayTeam                               // sayteam: line.
+	KindTeamScore                             // red: line with the team scores.
+	KindCTF                                   // CTF: line.
+	KindSeparator                             // Dashed line printed around each match.
+)
+
+// Token represents a line of the game log split into its timestamp, kind and arguments.
+// The strings are slices of the line, so a token is built without allocations.
+type Token struct {
+	Line string   // Line is the whole line.
+	Time GameTime // Time of the line.
+	Kind LineKind // Kind of the line.
+	Body string   // Body is the line after the timestamp, starting with the kind.
+	Args string   // Args is the body after the kind, like " 2 weapon_rocketlauncher" for an Item line.
+}
+
+// Tokenize splits a line in a single pass over its bytes, without regular expressions or allocations.
+// It returns false if the line does not start with a "mm:ss " timestamp, the leading spaces are ignored.
+func Tokenize(line string) (Token, bool) {
+	i := 0
+	for i < len(line) && isSpace(line[i]) {
+		i++
+	}
+
+	start := i
+	for i < len(line) && isDigit(line[i]) {
+		i++
+	}
+
+	if i == start || i+4 > len(line) || line[i] != ':' || !isDigit(line[i+1]) || !isDigit(line[i+2]) || line[i+3] != ' ' {
+		return Token{}, false
+	}
+
+	minutes, err := strconv.Atoi(line[start:i])
+	if err != nil {
+		return Token{}, false
+	}
+
+	token := Token{
+		Line: line,
+		Time: GameTime{Minutes: minutes, Seconds: int(line[i+1]-'0')*10 + int(line[i+2]-'0')},
+		Body: line[i+4:],
+	}
+	token.Kind, token.Args = classify(token.Body)
+
+	return token, true
+}
+
+// classify returns the kind of the body and the arguments after it.
+// The first byte selects the candidates, so each body is compared with at most a few prefixes.
+func classify(body string) (LineKind, string) {
+	if body == "" {
+		return KindUnknown, ""
+	}
+
+	for _, candidate := range kindsByFirstByte[body[0]] {
+		if args, ok := strings.CutPrefix(body, candidate.prefix); ok {
+			return candidate.kind, args
+		}
+	}
+
+	return KindUnknown, ""
+}
+
+// kindPrefix is the prefix of the body that identifies a kind of line.
+type kindPrefix struct {
+	prefix string
+	kind   LineKind
+}
+
+var kindsByFirstByte = [256][]kindPrefix{
+	'I': {{"InitGame:", KindInitGame}, {"Item:", KindItem}},
+	'E': {{"Exit:", KindExit}},
+	'S': {{"ShutdownGame:", KindShutdownGame}},
+	'C': {
+		{"ClientUserinfoChanged:", KindClientUserinfoChanged},
+		{"ClientConnect:", KindClientConnect},
+		{"ClientBegin:", KindClientBegin},
+		{"ClientDisconnect:", KindClientDisconnect},
+		{"CTF:", KindCTF},
+	},
+	'K': {{"Kill:", KindKill}},
+	's': {{"score:", KindScore}, {"say:", KindSay}, {"sayteam:", KindSayTeam}},
+	'r': {{"red:", KindTeamScore}},
+	'-': {{"---", KindSeparator}},
+}
+
+// ClientID returns the client id of the ClientConnect, ClientBegin and ClientDisconnect lines, like "ClientConnect: 2".
+func (t Token) ClientID() (int, error) {
+	id, err := strconv.Atoi(strings.TrimSpace(t.Args))
+	if err != nil {
+		return 0, fmt.Errorf("invalid client id in line: %s", t.Body)
+	}
+
+	return id, nil
+}
+
+// Kill returns the kill event of a "Kill: <killer> <killed> <mean>: <killer> killed <killed> by <mean>" line.
+func (t Token) Kill() (KillEvent, error) {
+	kill, ok := scanKill(t.Args)
+	if !ok {
+		return KillEvent{}, fmt.Errorf("invalid kill line: %s", t.Body)
+	}
+
+	kill.Time = t.Time
+	return kill, nil
+}
+
+// UserinfoChanged returns the client information of a "ClientUserinfoChanged: <client> n\<name>\t\<team>\..." line.
+// The team is optional, a client information without it is a player without team.
+func (t Token) UserinfoChanged() (ClientUserinfoChangedEvent, error) {
+	player, info, ok := scanUserinfo(t.Args)
+	if !ok {
+		return ClientUserinfoChangedEvent{}, fmt.Errorf("invalid user changed line: %s", t.Body)
+	}
+
+	team, err := scanUserTeam(info)
+	if err != nil {
+		return ClientUserinfoChangedEvent{}, fmt.Errorf("invalid team in line: %s", t.Body)
+	}
+
+	return ClientUserinfoChangedEvent{Time: t.Time, ClientID: player.ID, Name: player.Name, Team: team}, nil
+}
+
+// Item returns the item event of an "Item: <client> <item>" line.
+func (t Token) Item() (ItemEvent, error) {
+	c := newCursor(t.Args)
+	c.skip(" ")
+	id := c.uint()
+	c.skip(" ")
+	if !c.ok || c.rest == "" || strings.IndexFunc(c.rest, isSpaceRune) >= 0 {
+		return ItemEvent{}, fmt.Errorf("invalid item line: %s", t.Body)
+	}
+
+	return ItemEvent{Time: t.Time, ClientID: id, Item: c.rest}, nil
+}
+
+// Score returns the scoreboard line of a "score: <score>  ping: <ping>  client: <client> <name>" line.
+func (t Token) Score() (ScoreEvent, error) {
+	c := newCursor(t.Args)
+	c.skip(" ")
+	score := c.int()
+	c.spaces()
+	c.skip("ping: ")
+	ping := c.uint()
+	c.spaces()
+	c.skip("client: ")
+	id := c.uint()
+	c.skip(" ")
+	if !c.ok {
+		return ScoreEvent{}, fmt.Errorf("invalid score line: %s", t.Body)
+	}
+
+	return ScoreEvent{Time: t.Time, Score: score, Ping: ping, ClientID: id, Name: c.rest}, nil
+}
+
+// Say returns the chat message of a "say: <name>: <message>" or "sayteam: <name>: <message>" line.
+// The name ends at the first ": ", so the messages can contain it.
+func (t Token) Say() (SayEvent, error) {
+	c := newCursor(t.Args)
+	c.skip(" ")
+	name, message, found := strings.Cut(c.rest, ": ")
+	if !c.ok || !found {
+		return SayEvent{}, fmt.Errorf("invalid say line: %s", t.Body)
+	}
+
+	return SayEvent{Time: t.Time, Name: name, Message: message, TeamOnly: t.Kind == KindSayTeam}, nil
+}
+
+// TeamScore returns the team scores of a "red:<score>  blue:<score>" line.
+func (t Token) TeamScore() (TeamScoreEvent, error) {
+	c := newCursor(t.Args)
+	red := c.int()
+	c.spaces()
+	c.skip("blue:")
+	blue := c.int()
+	if !c.ok || c.rest != "" {
+		return TeamScoreEvent{}, fmt.Errorf("invalid team score line: %s", t.Body)
+	}
+
+	return TeamScoreEvent{Time: t.Time, Red: red, Blue: blue}, nil
+}
+
+// CTF returns the capture the flag event of a "CTF: <client> <team> <action>: <text>" line.
+func (t Token) CTF() (CTFEvent, error) {
+	c := newCursor(t.Args)
+	c.skip(" ")
+	id := c.uint()
+	c.skip(" ")
+	team := c.uint()
+	c.skip(" ")
+	action := c.uint()
+	c.skip(":")
+	if !c.ok {
+		return CTFEvent{}, fmt.Errorf("invalid ctf line: %s", t.Body)
+	}
+
+	return CTFEvent{Time: t.Time, ClientID: id, Team: Team(team), Action: CTFAction(action)}, nil
+}
+
+// scanKill extracts the fields of the kill arguments, like " 1022 2 22: <world> killed Isgalamido by MOD_TRIGGER_HURT".
+// The names are split at the last " killed " before the last " by ", as the server never escapes them,
+// so a name containing " killed " or " by " is kept whole whenever the line is not ambiguous.
+func scanKill(args string) (KillEvent, bool) {
+	c := newCursor(args)
+	c.skip(" ")
+	killerID := c.uint()
+	c.skip(" ")
+	killedID := c.uint()
+	c.skip(" ")
+	meanID := c.uint()
+	c.skip(": ")
+	if !c.ok {
+		return KillEvent{}, false
+	}
+
+	rest := c.rest
+	for by := strings.LastIndex(rest, " by "); by > 0; by = strings.LastIndex(rest[:by], " by ") {
+		head, cause := rest[:by], rest[by+len(" by "):]
+		killed := strings.LastIndex(head, " killed ")
+		if killed <= 0 || killed+len(" killed ") == len(head) || cause == "" {
+			continue
+		}
+
+		return KillEvent{
+			KillerID:   killerID,
+			KilledID:   killedID,
+			KillerName: head[:killed],
+			KilledName: head[killed+len(" killed "):],
+			DeathCause: cause,
+			MeanID:     meanID,
+		}, true
+	}
+
+	return KillEvent{}, false
+}
+
+// scanUserinfo extracts the client id and the name of the client information arguments, like " 2 n\Isgalamido\t\0\model\sarge".
+// The name ends at the last "\t\" of the line, so a name containing it is kept whole, and the information after that
+// boundary is returned to read the team.
+func scanUserinfo(args string) (Player, string, bool) {
+	c := newCursor(args)
+	c.skip(" ")
+	id := c.uint()
+	c.skip(` n\`)
+	if !c.ok {
+		return Player{}, "", false
+	}
+
+	end := strings.LastIndex(c.rest, `\t\`)
+	if end <= 0 {
+		return Player{}, "", false
+	}
+
+	return Player{ID: id, Name: c.rest[:end]}, c.rest[end+len(`\t\`):], true
+}
+
+// scanUserTeam returns the team at the start of the information after the name, or TeamFree if it has no team number.
+func scanUserTeam(info string) (Team, error) {
+	end := 0
+	for end < len(info) && isDigit(info[end]) {
+		end++
+	}
+
+	if end == 0 {
+		return TeamFree, nil
+	}
+
+	team, err := strconv.Atoi(info[:end])
+	return Team(team), err
+}
+
+// cursor reads the fields of the arguments from left to right.
+// A failed read sets ok to false and turns the next reads into no-ops, so the fields are checked once at the end.
+type cursor struct {
+	rest string // rest is the part of the arguments not read yet.
+	ok   bool   // ok is false if any read failed.
+}
+
+func newCursor(args string) cursor {
+	return cursor{rest: args, ok: true}
+}
+
+// skip reads the prefix.
+func (c *cursor) skip(prefix string) {
+	if !c.ok {
+		return
+	}
+
+	c.rest, c.ok = strings.CutPrefix(c.rest, prefix)
+}
+
+// spaces reads one or more white spaces.
+func (c *cursor) spaces() {
+	i := 0
+	for i < len(c.rest) && isSpace(c.rest[i]) {
+		i++
+	}
+
+	c.ok = c.ok && i > 0
+	c.rest = c.rest[i:]
+}
+
+// uint reads a number without sign.
+func (c *cursor) uint() int {
+	end := 0
+	for end < len(c.rest) && isDigit(c.rest[end]) {
+		end++
+	}
+
+	if !c.ok || end == 0 {
+		c.ok = false
+		return 0
+	}
+
+	value, err := strconv.Atoi(c.rest[:end])
+	c.ok = err == nil
+	c.rest = c.rest[end:]
+
+	return value
+}
+
+// int reads a number with an optional minus sign.
+func (c *cursor) int() int {
+	if c.ok && strings.HasPrefix(c.rest, "-") {
+		c.rest = c.rest[1:]
+		return -c.uint()
+	}
+
+	return c.uint()
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isSpace reports whether c is a white space byte of the log, the same set of the \s class of regular expressions.
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\f' || c == '\r'
+}
+
+func isSpaceRune(r rune) bool {
+	return r < 0x80 && isSpace(byte(r))
+}
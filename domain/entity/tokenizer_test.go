@@ -0,0 +1,473 @@
+package entity_test
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
+func TestTokenize(t *testing.T) {
+	type args struct {
+		line string
+	}
+
+	tests := []struct {
+		name   string
+		args   args
+		want   entity.Token
+		wantOk bool
+	}{
+		{
+			name: "Should split the timestamp, the kind and the arguments",
+			args: args{
+				line: ` 20:40 Item: 2 weapon_rocketlauncher`,
+			},
+			want: entity.Token{
+				Line: ` 20:40 Item: 2 weapon_rocketlauncher`,
+				Time: entity.GameTime{Minutes: 20, Seconds: 40},
+				Kind: entity.KindItem,
+				Body: "Item: 2 weapon_rocketlauncher",
+				Args: " 2 weapon_rocketlauncher",
+			},
+			wantOk: true,
+		},
+		{
+			name: "Should classify a sayteam line",
+			args: args{
+				line: `981:21 sayteam: Zeh: cover the flag`,
+			},
+			want: entity.Token{
+				Line: `981:21 sayteam: Zeh: cover the flag`,
+				Time: entity.GameTime{Minutes: 981, Seconds: 21},
+				Kind: entity.KindSayTeam,
+				Body: "sayteam: Zeh: cover the flag",
+				Args: " Zeh: cover the flag",
+			},
+			wantOk: true,
+		},
+		{
+			name: "Should classify a separator line",
+			args: args{
+				line: `  0:00 ------------------------------------------------------------`,
+			},
+			want: entity.Token{
+				Line: `  0:00 ------------------------------------------------------------`,
+				Kind: entity.KindSeparator,
+				Body: "------------------------------------------------------------",
+				Args: "---------------------------------------------------------",
+			},
+			wantOk: true,
+		},
+		{
+			name: "Should return an unknown kind if the kind is not recognised",
+			args: args{
+				line: ` 20:34 Something: 2`,
+			},
+			want: entity.Token{
+				Line: ` 20:34 Something: 2`,
+				Time: entity.GameTime{Minutes: 20, Seconds: 34},
+				Kind: entity.KindUnknown,
+				Body: "Something: 2",
+			},
+			wantOk: true,
+		},
+		{
+			name: "Should return false if the line has no timestamp",
+			args: args{
+				line: ` Kill: 1022 2 19: <world> killed Isgalamido by MOD_TRIGGER_HURT`,
+			},
+			wantOk: false,
+		},
+		{
+			name: "Should return false if the seconds do not have two digits",
+			args: args{
+				line: ` 20:4 Item: 2 weapon_rocketlauncher`,
+			},
+			wantOk: false,
+		},
+		{
+			name: "Should return false if the timestamp is not followed by a space",
+			args: args{
+				line: ` 20:40`,
+			},
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotOk := entity.Tokenize(tt.args.line)
+			if gotOk != tt.wantOk {
+				t.Errorf("Tokenize() gotOk = %v, want %v", gotOk, tt.wantOk)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tokenize() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLine_Fields(t *testing.T) {
+	type args struct {
+		line string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    entity.Event
+		wantErr bool
+	}{
+		{
+			name: "Should keep a name with the word killed",
+			args: args{
+				line: ` 22:06 Kill: 2 3 7: Isgalamido killed Mocinha killed Zeh by MOD_ROCKET_SPLASH`,
+			},
+			want: entity.KillEvent{
+				Time:       entity.GameTime{Minutes: 22, Seconds: 6},
+				KillerID:   2,
+				KilledID:   3,
+				KillerName: "Isgalamido killed Mocinha",
+				KilledName: "Zeh",
+				DeathCause: "MOD_ROCKET_SPLASH",
+				MeanID:     7,
+			},
+		},
+		{
+			name: "Should return error if the kill line has no cause",
+			args: args{
+				line: ` 22:06 Kill: 2 3 7: Isgalamido killed Mocinha`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error if the mean of the kill line is not a number",
+			args: args{
+				line: ` 22:06 Kill: 2 3 a: Isgalamido killed Mocinha by MOD_ROCKET_SPLASH`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return a player without team if the client information has no team",
+			args: args{
+				line: ` 20:34 ClientUserinfoChanged: 2 n\Isgalamido\t\\model\sarge`,
+			},
+			want: entity.ClientUserinfoChangedEvent{
+				Time:     entity.GameTime{Minutes: 20, Seconds: 34},
+				ClientID: 2,
+				Name:     "Isgalamido",
+				Team:     entity.TeamFree,
+			},
+		},
+		{
+			name: "Should read the team after the end of a name containing the team separator",
+			args: args{
+				line: ` 20:34 ClientUserinfoChanged: 2 n\Isga\t\0\t\2\model\sarge`,
+			},
+			want: entity.ClientUserinfoChangedEvent{
+				Time:     entity.GameTime{Minutes: 20, Seconds: 34},
+				ClientID: 2,
+				Name:     `Isga\t\0`,
+				Team:     entity.TeamBlue,
+			},
+		},
+		{
+			name: "Should return error if the client information has no name",
+			args: args{
+				line: ` 20:34 ClientUserinfoChanged: 2 n\\t\0\model\sarge`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error if the item has spaces",
+			args: args{
+				line: ` 20:40 Item: 2 weapon rocketlauncher`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should parse a score line with an empty name",
+			args: args{
+				line: ` 11:15 score: 20  ping: 4  client: 1 `,
+			},
+			want: entity.ScoreEvent{Time: entity.GameTime{Minutes: 11, Seconds: 15}, Score: 20, Ping: 4, ClientID: 1},
+		},
+		{
+			name: "Should return error if the score line has no ping",
+			args: args{
+				line: ` 11:15 score: 20  client: 1 Zeh`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should split a say line at the first separator",
+			args: args{
+				line: ` 12:02 say: Zeh: note: cover the flag`,
+			},
+			want: entity.SayEvent{Time: entity.GameTime{Minutes: 12, Seconds: 2}, Name: "Zeh", Message: "note: cover the flag"},
+		},
+		{
+			name: "Should parse negative team scores",
+			args: args{
+				line: ` 10:12 red:-1  blue:6`,
+			},
+			want: entity.TeamScoreEvent{Time: entity.GameTime{Minutes: 10, Seconds: 12}, Red: -1, Blue: 6},
+		},
+		{
+			name: "Should return error if the team score line has extra text",
+			args: args{
+				line: ` 10:12 red:8  blue:6 green:1`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error if the ctf line has no colon",
+			args: args{
+				line: ` 10:09 CTF: 7 2 1 Assasinu Credi captured the RED flag!`,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := entity.ParseLine(tt.args.line)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseLine() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLine() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenize_Allocations(t *testing.T) {
+	lines := []string{
+		` 22:06 Kill: 2 3 7: Isgalamido killed Mocinha by MOD_ROCKET_SPLASH`,
+		` 10:28 ClientUserinfoChanged: 2 n\Isgalamido\t\1\model\uriel/zael\hmodel\uriel/zael`,
+		` 20:40 Item: 2 weapon_rocketlauncher`,
+		` 11:15 score: -3  ping: 15  client: 6 Assasinu Credi`,
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		for _, line := range lines {
+			token, _ := entity.Tokenize(line)
+			switch token.Kind {
+			case entity.KindKill:
+				_, _ = token.Kill()
+			case entity.KindClientUserinfoChanged:
+				_, _ = token.UserinfoChanged()
+			case entity.KindItem:
+				_, _ = token.Item()
+			case entity.KindScore:
+				_, _ = token.Score()
+			}
+		}
+	})
+
+	if allocs != 0 {
+		t.Errorf("Tokenize() allocs = %v, want 0", allocs)
+	}
+}
+
+func TestParseLine_SameAsRegex(t *testing.T) {
+	lines, _ := readSampleLog(t)
+
+	for i, line := range lines {
+		want, wantErr := parseLineRegex(line)
+		got, err := entity.ParseLine(line)
+		if (err != nil) != (wantErr != nil) || !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseLine() line %d got = %+v, %v, want %+v, %v", i+1, got, err, want, wantErr)
+		}
+	}
+}
+
+// BenchmarkParseLine compares the tokenizer with the regular expressions it replaced, over the lines of the sample log.
+// Run it with: go test -bench ParseLine -benchmem ./domain/entity
+func BenchmarkParseLine(b *testing.B) {
+	lines, size := readSampleLog(b)
+
+	b.Run("regex", func(b *testing.B) {
+		b.SetBytes(size)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, line := range lines {
+				_, _ = parseLineRegex(line)
+			}
+		}
+	})
+
+	b.Run("tokenizer", func(b *testing.B) {
+		b.SetBytes(size)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, line := range lines {
+				_, _ = entity.ParseLine(line)
+			}
+		}
+	})
+}
+
+// BenchmarkTokenize measures the tokenizer alone, without building the events.
+func BenchmarkTokenize(b *testing.B) {
+	lines, size := readSampleLog(b)
+
+	b.SetBytes(size)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, line := range lines {
+			token, _ := entity.Tokenize(line)
+			switch token.Kind {
+			case entity.KindKill:
+				_, _ = token.Kill()
+			case entity.KindClientUserinfoChanged:
+				_, _ = token.UserinfoChanged()
+			case entity.KindItem:
+				_, _ = token.Item()
+			}
+		}
+	}
+}
+
+// readSampleLog returns the lines of the sample log in the root of the repository and their size in bytes.
+func readSampleLog(tb testing.TB) ([]string, int64) {
+	tb.Helper()
+
+	data, err := os.ReadFile("../../qgames.log")
+	if err != nil {
+		tb.Skipf("sample log not found: %v", err)
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	return lines, int64(len(data))
+}
+
+// The regular expressions below are the implementation replaced by the tokenizer, kept as the baseline of the benchmarks.
+var (
+	regexLine      = regexp.MustCompile(`^\s*(\d+):(\d{2}) (.*)$`)
+	regexKill      = regexp.MustCompile(`(\d+:\d+) Kill: (\d+) (\d+) (\d+): (.+) killed (.+) by (.+)`)
+	regexUser      = regexp.MustCompile(`(\d+:\d+) ClientUserinfoChanged: (\d+) n\\(.+)\\t\\`)
+	regexUserTeam  = regexp.MustCompile(`\\t\\(\d+)`)
+	regexItem      = regexp.MustCompile(`^Item: (\d+) (\S+)$`)
+	regexScore     = regexp.MustCompile(`^score: (-?\d+)\s+ping: (\d+)\s+client: (\d+) (.*)$`)
+	regexSay       = regexp.MustCompile(`^say(team)?: (.*?): (.*)$`)
+	regexTeamScore = regexp.MustCompile(`^red:(-?\d+)\s+blue:(-?\d+)$`)
+)
+
+func parseLineRegex(line string) (entity.Event, error) {
+	matches := regexLine.FindStringSubmatch(line)
+	if len(matches) != 4 {
+		return nil, fmt.Errorf("invalid timestamp in line: %s", line)
+	}
+
+	minutes, _ := strconv.Atoi(matches[1])
+	seconds, _ := strconv.Atoi(matches[2])
+	gameTime := entity.GameTime{Minutes: minutes, Seconds: seconds}
+	body := matches[3]
+
+	switch {
+	case strings.HasPrefix(body, "InitGame:"):
+		return entity.InitGameEvent{Time: gameTime, Config: entity.ParseMatchConfig(strings.TrimSpace(strings.TrimPrefix(body, "InitGame:")))}, nil
+	case strings.HasPrefix(body, "Exit:"):
+		return entity.ExitEvent{Time: gameTime, Reason: strings.TrimSpace(strings.TrimPrefix(body, "Exit:"))}, nil
+	case strings.HasPrefix(body, "ShutdownGame:"):
+		return entity.ShutdownGameEvent{Time: gameTime}, nil
+	case strings.HasPrefix(body, "ClientConnect:"):
+		id, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(body, "ClientConnect:")))
+		return entity.ClientConnectEvent{Time: gameTime, ClientID: id}, err
+	case strings.HasPrefix(body, "ClientUserinfoChanged:"):
+		matches := regexUser.FindStringSubmatch(line)
+		if len(matches) != 4 {
+			return nil, fmt.Errorf("invalid number of matches in user changed line: %s", line)
+		}
+
+		id, _ := strconv.Atoi(matches[2])
+		team := entity.TeamFree
+		if teamMatches := regexUserTeam.FindStringSubmatch(line); len(teamMatches) == 2 {
+			value, _ := strconv.Atoi(teamMatches[1])
+			team = entity.Team(value)
+		}
+
+		return entity.ClientUserinfoChangedEvent{Time: gameTime, ClientID: id, Name: matches[3], Team: team}, nil
+	case strings.HasPrefix(body, "ClientBegin:"):
+		id, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(body, "ClientBegin:")))
+		return entity.ClientBeginEvent{Time: gameTime, ClientID: id}, err
+	case strings.HasPrefix(body, "ClientDisconnect:"):
+		id, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(body, "ClientDisconnect:")))
+		return entity.ClientDisconnectEvent{Time: gameTime, ClientID: id}, err
+	case strings.HasPrefix(body, "Kill:"):
+		matches := regexKill.FindStringSubmatch(line)
+		if len(matches) != 8 {
+			return nil, fmt.Errorf("invalid number of matches in kill line: %s", line)
+		}
+
+		killerID, _ := strconv.Atoi(matches[2])
+		killedID, _ := strconv.Atoi(matches[3])
+		meanID, _ := strconv.Atoi(matches[4])
+
+		return entity.KillEvent{
+			Time:       gameTime,
+			KillerID:   killerID,
+			KilledID:   killedID,
+			KillerName: matches[5],
+			KilledName: matches[6],
+			DeathCause: matches[7],
+			MeanID:     meanID,
+		}, nil
+	case strings.HasPrefix(body, "Item:"):
+		matches := regexItem.FindStringSubmatch(body)
+		if len(matches) != 3 {
+			return nil, fmt.Errorf("invalid number of matches in item line: %s", body)
+		}
+
+		id, _ := strconv.Atoi(matches[1])
+		return entity.ItemEvent{Time: gameTime, ClientID: id, Item: matches[2]}, nil
+	case strings.HasPrefix(body, "score:"):
+		matches := regexScore.FindStringSubmatch(body)
+		if len(matches) != 5 {
+			return nil, fmt.Errorf("invalid number of matches in score line: %s", body)
+		}
+
+		score, _ := strconv.Atoi(matches[1])
+		ping, _ := strconv.Atoi(matches[2])
+		id, _ := strconv.Atoi(matches[3])
+		return entity.ScoreEvent{Time: gameTime, Score: score, Ping: ping, ClientID: id, Name: matches[4]}, nil
+	case strings.HasPrefix(body, "say:"), strings.HasPrefix(body, "sayteam:"):
+		matches := regexSay.FindStringSubmatch(body)
+		if len(matches) != 4 {
+			return nil, fmt.Errorf("invalid number of matches in say line: %s", body)
+		}
+
+		return entity.SayEvent{Time: gameTime, Name: matches[2], Message: matches[3], TeamOnly: matches[1] != ""}, nil
+	case strings.HasPrefix(body, "red:"):
+		matches := regexTeamScore.FindStringSubmatch(body)
+		if len(matches) != 3 {
+			return nil, fmt.Errorf("invalid number of matches in team score line: %s", body)
+		}
+
+		red, _ := strconv.Atoi(matches[1])
+		blue, _ := strconv.Atoi(matches[2])
+		return entity.TeamScoreEvent{Time: gameTime, Red: red, Blue: blue}, nil
+	case strings.HasPrefix(body, "---"):
+		return entity.SeparatorEvent{Time: gameTime}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", entity.ErrUnknownEvent, line)
+}
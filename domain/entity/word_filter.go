@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"strings"
+	"unicode"
+)
+
+// WordFilter represents a list of words to flag on the chat messages.
+type WordFilter map[string]bool
+
+// NewWordFilter creates a filter of the words, which are matched ignoring case.
+func NewWordFilter(words []string) WordFilter {
+	filter := make(WordFilter)
+	for _, word := range words {
+		if word = strings.TrimSpace(word); word != "" {
+			filter[strings.ToLower(word)] = true
+		}
+	}
+
+	return filter
+}
+
+// Match returns the words of the filter found in the message, in the order they appear.
+// Only whole words are matched, so a filtered word inside another word is not flagged.
+func (f WordFilter) Match(message string) []string {
+	var matched []string
+
+	words := strings.FieldsFunc(strings.ToLower(message), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		if f[word] {
+			matched = append(matched, word)
+		}
+	}
+
+	return matched
+}
@@ -0,0 +1,30 @@
+package entity_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
+func TestWordFilter_Match(t *testing.T) {
+	filter := entity.NewWordFilter([]string{"Noob", " camper ", ""})
+
+	tests := []struct {
+		name    string
+		message string
+		want    []string
+	}{
+		{name: "Should match the words ignoring case and punctuation", message: "NOOB camper!!", want: []string{"noob", "camper"}},
+		{name: "Should not match a word inside another word", message: "noobie campers", want: nil},
+		{name: "Should not match a clean message", message: "gg wp", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := filter.Match(tt.message); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Match() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
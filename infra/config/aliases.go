@@ -0,0 +1,39 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// aliasesFile represents the content of an aliases file.
+type aliasesFile struct {
+	Aliases map[string][]string `yaml:"aliases"`
+}
+
+// LoadAliases reads the player aliases of a YAML or JSON file, where each canonical name lists the other names of the player.
+// It returns the mapping of each name, including the canonical one, to the canonical name.
+func LoadAliases(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error to read aliases file: %w", err)
+	}
+
+	var file aliasesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("error to decode aliases file: %w", err)
+	}
+
+	aliases := make(map[string]string)
+	for canonical, names := range file.Aliases {
+		for _, name := range append(names, canonical) {
+			if other, ok := aliases[name]; ok && other != canonical {
+				return nil, fmt.Errorf("invalid aliases file: %q is an alias of %q and %q", name, other, canonical)
+			}
+			aliases[name] = canonical
+		}
+	}
+
+	return aliases, nil
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadAliases(t *testing.T) {
+	t.Run("Should map every name to the canonical name", func(t *testing.T) {
+		path := writeConfigFile(t, "aliases.yaml", `
+aliases:
+  Isgalamido: [Isga, Isgalamido2]
+  Zeh: [Zezinho]
+`)
+
+		aliases, err := LoadAliases(path)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{
+			"Isgalamido":  "Isgalamido",
+			"Isga":        "Isgalamido",
+			"Isgalamido2": "Isgalamido",
+			"Zeh":         "Zeh",
+			"Zezinho":     "Zeh",
+		}, aliases)
+	})
+
+	t.Run("Should return error if a name is an alias of two players", func(t *testing.T) {
+		path := writeConfigFile(t, "aliases.json", `{"aliases": {"Isgalamido": ["Player"], "Zeh": ["Player"]}}`)
+
+		_, err := LoadAliases(path)
+		require.Error(t, err)
+	})
+
+	t.Run("Should return error if the file does not exist", func(t *testing.T) {
+		_, err := LoadAliases(filepath.Join(t.TempDir(), "missing.yaml"))
+		require.Error(t, err)
+	})
+}
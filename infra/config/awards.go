@@ -0,0 +1,35 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/diegoclair/log-parser/domain/entity"
+	"gopkg.in/yaml.v3"
+)
+
+// awardsFile represents the content of an awards file.
+type awardsFile struct {
+	Awards []entity.AwardRule `yaml:"awards"`
+}
+
+// LoadAwards reads the award rules of a YAML or JSON file, as JSON is also valid YAML.
+func LoadAwards(path string) ([]entity.AwardRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error to read awards file: %w", err)
+	}
+
+	var file awardsFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("error to decode awards file: %w", err)
+	}
+
+	for _, rule := range file.Awards {
+		if err := rule.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid awards file: %w", err)
+		}
+	}
+
+	return file.Awards, nil
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/diegoclair/log-parser/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestLoadAwards(t *testing.T) {
+	want := []entity.AwardRule{
+		{Name: "Rocket man", Metric: entity.AwardMetricKillsByMeans, Means: []string{"MOD_ROCKET", "MOD_ROCKET_SPLASH"}},
+		{Name: "Pacifist", Metric: entity.AwardMetricFrags, Lowest: true},
+	}
+
+	t.Run("Should load a YAML file", func(t *testing.T) {
+		path := writeConfigFile(t, "awards.yaml", `
+awards:
+  - name: Rocket man
+    metric: kills_by_means
+    means: [MOD_ROCKET, MOD_ROCKET_SPLASH]
+  - name: Pacifist
+    metric: frags
+    lowest: true
+`)
+
+		awards, err := LoadAwards(path)
+		require.NoError(t, err)
+		require.Equal(t, want, awards)
+	})
+
+	t.Run("Should load a JSON file", func(t *testing.T) {
+		path := writeConfigFile(t, "awards.json", `{"awards": [
+			{"name": "Rocket man", "metric": "kills_by_means", "means": ["MOD_ROCKET", "MOD_ROCKET_SPLASH"]},
+			{"name": "Pacifist", "metric": "frags", "lowest": true}
+		]}`)
+
+		awards, err := LoadAwards(path)
+		require.NoError(t, err)
+		require.Equal(t, want, awards)
+	})
+
+	t.Run("Should return error if a rule is invalid", func(t *testing.T) {
+		path := writeConfigFile(t, "awards.yaml", "awards:\n  - name: Camper\n    metric: camping\n")
+
+		_, err := LoadAwards(path)
+		require.Error(t, err)
+	})
+
+	t.Run("Should return error if the file does not exist", func(t *testing.T) {
+		_, err := LoadAwards(filepath.Join(t.TempDir(), "missing.yaml"))
+		require.Error(t, err)
+	})
+}
@@ -1,14 +1,33 @@
 package config
 
+import (
+	"runtime"
+	"time"
+
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
 type Config struct {
-	AppName  string
-	LogDebug bool
+	AppName         string
+	LogDebug        bool
+	MultiKillWindow time.Duration      // MultiKillWindow is the maximum time between two kills of a player to count them as a multi-kill.
+	Awards          []entity.AwardRule // Awards are the award rules evaluated at the end of each match and of all the matches.
+	Aliases         map[string]string  // Aliases is the mapping of player names to the canonical name of the player.
+	Chat            bool               // Chat enables the chat section of each match report.
+	WordFilter      entity.WordFilter  // WordFilter is the word list flagging the chat messages on the moderation report.
+	Timeline        bool               // Timeline enables the recording of the events of each match, for the timeline export.
+	GroupByWeapon   bool               // GroupByWeapon aggregates the kills and deaths of the reports by weapon instead of by MOD_* mean.
+	Strict          bool               // Strict stops the extraction on the first malformed line.
+	ScoreInterval   time.Duration      // ScoreInterval is the time between the samples of the score progression, which is disabled if zero.
+	Workers         int                // Workers is the number of games parsed concurrently.
 }
 
 // GetDefaultConfig returns the default configuration
 func GetDefaultConfig() *Config {
 	return &Config{
-		AppName:  "log-parser",
-		LogDebug: true,
+		AppName:         "log-parser",
+		LogDebug:        true,
+		MultiKillWindow: 2 * time.Second,
+		Workers:         runtime.NumCPU(),
 	}
 }
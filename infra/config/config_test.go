@@ -1,7 +1,9 @@
 package config
 
 import (
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -11,4 +13,6 @@ func TestGetDefaultConfig(t *testing.T) {
 	require.NotNil(t, cfg)
 	require.Equal(t, "log-parser", cfg.AppName)
 	require.True(t, cfg.LogDebug)
+	require.Equal(t, 2*time.Second, cfg.MultiKillWindow)
+	require.Equal(t, runtime.NumCPU(), cfg.Workers)
 }
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LoadWordList reads the words of a text file, one word per line. Empty lines and lines starting with # are ignored.
+func LoadWordList(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error to read word list file: %w", err)
+	}
+	defer file.Close()
+
+	var words []string
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		words = append(words, word)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error to read word list file: %w", err)
+	}
+
+	return words, nil
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadWordList(t *testing.T) {
+	t.Run("Should read one word per line skipping comments and empty lines", func(t *testing.T) {
+		path := writeConfigFile(t, "words.txt", "# insults\nnoob\n\n  camper  \n")
+
+		words, err := LoadWordList(path)
+		require.NoError(t, err)
+		require.Equal(t, []string{"noob", "camper"}, words)
+	})
+
+	t.Run("Should return error if the file does not exist", func(t *testing.T) {
+		_, err := LoadWordList(filepath.Join(t.TempDir(), "missing.txt"))
+		require.Error(t, err)
+	})
+}
@@ -33,7 +33,13 @@ func (w *writer) StartWriting(ctx context.Context, data <-chan dto.Report) {
 		reports[report.GameName] = report
 	}
 
-	jsonData, err := json.Marshal(reports)
+	w.Write(ctx, reports)
+}
+
+// Write marshals the data into JSON format and writes it to the specified file.
+// If there is an error during marshaling or writing, it logs the error using the logger.Logger.
+func (w *writer) Write(ctx context.Context, data any) {
+	jsonData, err := json.Marshal(data)
 	if err != nil {
 		w.log.Errorf(ctx, "Error to marshal data: %v", err)
 	}
@@ -43,3 +49,15 @@ func (w *writer) StartWriting(ctx context.Context, data <-chan dto.Report) {
 		w.log.Errorf(ctx, "Error to write data: %v", err)
 	}
 }
+
+// WriteLines marshals each item into JSON format and writes it to the specified file on its own line, as NDJSON.
+// If there is an error during marshaling or writing, it logs the error using the logger.Logger and stops writing.
+func (w *writer) WriteLines(ctx context.Context, items []any) {
+	encoder := json.NewEncoder(w.file)
+	for _, item := range items {
+		if err := encoder.Encode(item); err != nil {
+			w.log.Errorf(ctx, "Error to write data: %v", err)
+			return
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package writer_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -125,3 +126,40 @@ func TestWriter_StartWriting(t *testing.T) {
 		})
 	}
 }
+
+func TestWriter_Write(t *testing.T) {
+	ctx := context.Background()
+	writerMock := newWriterMock()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	loggerMock := logger.NewMockLogger(ctrl)
+
+	wr := writer.NewWriter(writerMock, loggerMock)
+
+	data := dto.HeadToHead{"player1": {"player2": 3}}
+	wr.Write(ctx, data)
+
+	b, err := json.Marshal(data)
+	require.NoError(t, err)
+	require.Equal(t, b, writerMock.wroteBytes())
+}
+
+func TestWriter_WriteLines(t *testing.T) {
+	ctx := context.Background()
+	buffer := &bytes.Buffer{}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	loggerMock := logger.NewMockLogger(ctrl)
+
+	wr := writer.NewWriter(buffer, loggerMock)
+
+	wr.WriteLines(ctx, []any{
+		dto.TimelineEvent{Time: "0:15", Elapsed: 15, Type: dto.TimelineEventBegin, Player: "player1"},
+		dto.TimelineEvent{Time: "0:20", Elapsed: 20, Type: dto.TimelineEventShutdown},
+	})
+
+	require.Equal(t, "{\"time\":\"0:15\",\"elapsed\":15,\"type\":\"begin\",\"player\":\"player1\"}\n"+
+		"{\"time\":\"0:20\",\"elapsed\":20,\"type\":\"shutdown\"}\n", buffer.String())
+}
@@ -1,13 +1,26 @@
 package scripts
 
 import (
-	"bufio"
+	"cmp"
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"strings"
 
 	"github.com/diegoclair/go_utils/logger"
+	"github.com/diegoclair/log-parser/application/dto"
+	"github.com/diegoclair/log-parser/domain/entity"
 )
 
+const (
+	readChunkSize = 1 << 20 // readChunkSize is the size of the reads of the log files.
+	maxLineSize   = 1 << 20 // maxLineSize is the size of the longest line of the log files.
+)
+
+// ErrLineTooLong is returned when a line of a log file is longer than maxLineSize bytes.
+var ErrLineTooLong = errors.New("line too long")
+
 // QuakeLogParser is a struct that represents a Quake log parser.
 type QuakeLogParser struct {
 	log logger.Logger
@@ -21,12 +34,166 @@ func NewQuakeLogParser(log logger.Logger) *QuakeLogParser {
 }
 
 // ReadLinesFromQuakeLog reads lines from a Quake log file and sends them to lineChan channel.
-func (q *QuakeLogParser) ReadLinesFromQuakeLog(ctx context.Context, file io.Reader, lineChan chan<- string) {
+func (q *QuakeLogParser) ReadLinesFromQuakeLog(ctx context.Context, file io.Reader, lineChan chan<- dto.LogLine) error {
+	return q.ReadLinesFromQuakeLogs(ctx, []io.Reader{file}, lineChan)
+}
+
+// ReadLinesFromQuakeLogs reads lines from several Quake log files, one after the other, and sends them to lineChan channel.
+// The lines have their number and byte offset in their file, and the file name if the reader is a file.
+// It is not used by the application, which reads the logs with ReadGamesFromQuakeLogs: it is kept to feed the sequential reference
+// extraction of the service, which ends the game in progress on the first line of each file.
+// It stops reading when the context is done, and returns the error of a file that could not be read until the end.
+func (q *QuakeLogParser) ReadLinesFromQuakeLogs(ctx context.Context, files []io.Reader, lineChan chan<- dto.LogLine) error {
 	defer close(lineChan)
 
-	scanner := bufio.NewScanner(file)
+	for _, file := range files {
+		if err := q.readLines(file, func(line dto.LogLine) bool { return sendTo(ctx, line, lineChan) }); err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// ReadGamesFromQuakeLogs reads lines from several Quake log files, like ReadLinesFromQuakeLogs, and sends them to segmentsChan channel
+// grouped by game, in segments that start at each InitGame line. The lines before the first game are sent as the segment of game 0.
+// The files are numbered as a single log, but a game does not continue on the next file: the segment of the game in progress at the
+// end of a file is sent as the last one of the file, and the lines of the next file before its first InitGame line are sent at the start
+// of the segment of the next game, where they are outside of any game.
+// It stops reading when the context is done, and returns the error of a file that could not be read until the end.
+func (q *QuakeLogParser) ReadGamesFromQuakeLogs(ctx context.Context, files []io.Reader, segmentsChan chan<- dto.GameSegment) error {
+	defer close(segmentsChan)
+
+	segment := dto.GameSegment{}
+	inGame := false // inGame is true if the segment has the InitGame line of its game.
+	for _, file := range files {
+		err := q.readLines(file, func(line dto.LogLine) bool {
+			if line.IsInitGame() {
+				// the segment of game 0 is sent even without lines, so the segments are numbered without gaps
+				if inGame || segment.Game == 0 {
+					if !sendTo(ctx, segment, segmentsChan) {
+						return false
+					}
+					segment = dto.GameSegment{Game: segment.Game + 1}
+				}
+				inGame = true
+			}
+
+			segment.Lines = append(segment.Lines, line)
+			return true
+		})
+		if err != nil || ctx.Err() != nil {
+			return err
+		}
+
+		if inGame {
+			segment.Last = true
+			if !sendTo(ctx, segment, segmentsChan) {
+				return nil
+			}
+			segment, inGame = dto.GameSegment{Game: segment.Game + 1}, false
+		}
+	}
+
+	// the segment of game 0 without any game, or the lines after the last game that were not followed by another one
+	if segment.Game == 0 || len(segment.Lines) > 0 {
+		segment.Last = true
+		sendTo(ctx, segment, segmentsChan)
+	}
+
+	return nil
+}
+
+// readLines reads the lines of the file and calls send with each line, split by entity.Tokenize.
+// The file is read in chunks of readChunkSize bytes, each one converted to a string once, so the lines are slices of their chunk
+// and only the lines cut by the end of a chunk are copied. The reading stops without error if send returns false,
+// and it returns the error of the file, like ErrLineTooLong.
+func (q *QuakeLogParser) readLines(file io.Reader, send func(line dto.LogLine) bool) error {
+	source := ""
+	if named, ok := file.(interface{ Name() string }); ok {
+		source = named.Name()
+	}
+
+	sendLine := func(text string, number int, offset int64) bool {
+		text = strings.TrimSuffix(text, "\r")
+		token, ok := entity.Tokenize(text)
+
+		// a glued line is sent as its partial record, which truncates the game, and the records glued after it
+		gluedAfter := false
+		for {
+			partial, record, recordToken, glued := splitGluedLine(text, token, ok)
+			if !glued {
+				break
+			}
+
+			if !send(dto.LogLine{Text: partial, Source: source, Number: number, Offset: offset, Partial: true, Glued: gluedAfter}) {
+				return false
+			}
+			text, token, ok, offset = record, recordToken, true, offset+int64(len(partial))
+			gluedAfter = true
+		}
+
+		return send(dto.LogLine{Text: text, Token: token, Tokenized: ok, Source: source, Number: number, Offset: offset, Glued: gluedAfter})
+	}
+
+	buf := make([]byte, readChunkSize)
+	number := 0
+	var offset int64 // offset is the byte offset of the start of the next line.
+	rest := ""       // rest is the start of the next line, cut by the end of the last chunk.
+	for {
+		n, err := file.Read(buf)
+		chunk := string(buf[:n])
+
+		for {
+			end := strings.IndexByte(chunk, '\n')
+			if end < 0 {
+				break
+			}
+
+			text := chunk[:end]
+			if rest != "" {
+				text, rest = rest+text, ""
+			}
+			chunk = chunk[end+1:]
+
+			if len(text) > maxLineSize {
+				return fmt.Errorf("error to read %s at the byte %d: %w", cmp.Or(source, "the log"), offset, ErrLineTooLong)
+			}
+
+			number++
+			if !sendLine(text, number, offset) {
+				return nil
+			}
+			offset += int64(len(text) + 1)
+		}
+
+		rest += chunk
+		if len(rest) > maxLineSize {
+			return fmt.Errorf("error to read %s at the byte %d: %w", cmp.Or(source, "the log"), offset, ErrLineTooLong)
+		}
+
+		if errors.Is(err, io.EOF) {
+			// the last line of the file has no line break
+			if rest != "" {
+				sendLine(rest, number+1, offset)
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error to read %s at the byte %d: %w", cmp.Or(source, "the log"), offset+int64(len(rest)), err)
+		}
+	}
+}
 
-	for scanner.Scan() {
-		lineChan <- scanner.Text()
+// sendTo sends the item to the channel, and returns false if the context is done before.
+func sendTo[T any](ctx context.Context, item T, channel chan<- T) bool {
+	select {
+	case channel <- item:
+		return true
+	case <-ctx.Done():
+		return false
 	}
 }
@@ -2,11 +2,16 @@ package scripts_test
 
 import (
 	"context"
+	"errors"
 	"io"
+	"reflect"
 	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/diegoclair/go_utils/logger"
+	"github.com/diegoclair/log-parser/application/dto"
+	"github.com/diegoclair/log-parser/domain/entity"
 	"github.com/diegoclair/log-parser/transport/scripts"
 )
 
@@ -15,7 +20,7 @@ func TestReadLinesFromQuakeLog(t *testing.T) {
 
 	type args struct {
 		file     io.Reader
-		lineChan chan string
+		lineChan chan dto.LogLine
 	}
 	tests := []struct {
 		name string
@@ -26,7 +31,7 @@ func TestReadLinesFromQuakeLog(t *testing.T) {
 			name: "should read lines from file",
 			args: args{
 				file:     strings.NewReader("line1\nline2\nline3"),
-				lineChan: make(chan string),
+				lineChan: make(chan dto.LogLine),
 			},
 			want: []string{"line1", "line2", "line3"},
 		},
@@ -37,7 +42,7 @@ func TestReadLinesFromQuakeLog(t *testing.T) {
 
 			go func() {
 				for line := range tt.args.lineChan {
-					if line != tt.want[0] {
+					if line.Text != tt.want[0] {
 						t.Errorf("ReadLinesFromQuakeLog() = %v, want %v", line, tt.want[0])
 					}
 					tt.want = tt.want[1:]
@@ -48,3 +53,187 @@ func TestReadLinesFromQuakeLog(t *testing.T) {
 		})
 	}
 }
+
+func TestReadLinesFromQuakeLogs(t *testing.T) {
+	ctx := context.Background()
+	lineChan := make(chan dto.LogLine)
+	files := []io.Reader{
+		strings.NewReader("line1\r\nline2"),
+		strings.NewReader("\nline3\n"),
+	}
+
+	go scripts.NewQuakeLogParser(logger.NewNoop()).ReadLinesFromQuakeLogs(ctx, files, lineChan)
+
+	got := []dto.LogLine{}
+	for line := range lineChan {
+		got = append(got, line)
+	}
+
+	// the number and the offset are relative to each file, and count the dropped line breaks
+	want := []dto.LogLine{
+		tokenized(dto.LogLine{Text: "line1", Number: 1, Offset: 0}),
+		tokenized(dto.LogLine{Text: "line2", Number: 2, Offset: 7}),
+		tokenized(dto.LogLine{Text: "", Number: 1, Offset: 0}),
+		tokenized(dto.LogLine{Text: "line3", Number: 2, Offset: 1}),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadLinesFromQuakeLogs() = %v, want %v", got, want)
+	}
+}
+
+func TestReadLinesFromQuakeLogs_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	lineChan := make(chan dto.LogLine)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scripts.NewQuakeLogParser(logger.NewNoop()).ReadLinesFromQuakeLogs(ctx, []io.Reader{strings.NewReader("line1\nline2")}, lineChan)
+	}()
+
+	if line := <-lineChan; line.Text != "line1" {
+		t.Errorf("ReadLinesFromQuakeLogs() = %v, want line1", line.Text)
+	}
+
+	// nobody reads the second line, so the reader must stop on the cancel and close the channel
+	cancel()
+	<-done
+
+	for line := range lineChan {
+		t.Errorf("ReadLinesFromQuakeLogs() sent %v after the cancel", line.Text)
+	}
+}
+
+func TestReadLinesFromQuakeLogs_Error(t *testing.T) {
+	lineChan := make(chan dto.LogLine)
+	files := []io.Reader{strings.NewReader("line1\n" + strings.Repeat("a", 2<<20) + "\nline3\n")}
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- scripts.NewQuakeLogParser(logger.NewNoop()).ReadLinesFromQuakeLogs(context.Background(), files, lineChan)
+	}()
+
+	got := []string{}
+	for line := range lineChan {
+		got = append(got, line.Text)
+	}
+
+	// a line longer than the limit stops the reading, as the lines after it would be read out of their place
+	if !reflect.DeepEqual(got, []string{"line1"}) {
+		t.Errorf("ReadLinesFromQuakeLogs() = %v, want [line1]", got)
+	}
+	if err := <-errChan; !errors.Is(err, scripts.ErrLineTooLong) {
+		t.Errorf("ReadLinesFromQuakeLogs() error = %v, want %v", err, scripts.ErrLineTooLong)
+	}
+}
+
+func TestReadLinesFromQuakeLogs_GluedLines(t *testing.T) {
+	ctx := context.Background()
+	lineChan := make(chan dto.LogLine)
+	files := []io.Reader{strings.NewReader(strings.Join([]string{
+		` 26:09 Item: 2 weapon_rocketlauncher`,
+		` 26  0:00 ------------------------------------------------------------`,
+		` 12:10 Item: 2 weapon_ro  0:00 InitGame: \sv_hostname\Code Miner Server`,
+		` 12:11 say: Isgalamido: see you at 12:30 Item: at the rail`,
+	}, "\n"))}
+
+	go scripts.NewQuakeLogParser(logger.NewNoop()).ReadLinesFromQuakeLogs(ctx, files, lineChan)
+
+	got := []dto.LogLine{}
+	for line := range lineChan {
+		got = append(got, line)
+	}
+
+	want := []dto.LogLine{
+		tokenized(dto.LogLine{Text: ` 26:09 Item: 2 weapon_rocketlauncher`, Number: 1, Offset: 0}),
+		{Text: ` 26`, Number: 2, Offset: 37, Partial: true},
+		tokenized(dto.LogLine{Text: `  0:00 ------------------------------------------------------------`, Number: 2, Offset: 40, Glued: true}),
+		{Text: ` 12:10 Item: 2 weapon_ro`, Number: 3, Offset: 108, Partial: true},
+		tokenized(dto.LogLine{Text: `  0:00 InitGame: \sv_hostname\Code Miner Server`, Number: 3, Offset: 132, Glued: true}),
+		tokenized(dto.LogLine{Text: ` 12:11 say: Isgalamido: see you at 12:30 Item: at the rail`, Number: 4, Offset: 180}),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadLinesFromQuakeLogs() = %v, want %v", got, want)
+	}
+}
+
+func TestReadLinesFromQuakeLogs_ShortReads(t *testing.T) {
+	text := " 20:40 Item: 2 weapon_rocketlauncher\r\n 26  0:00 ------------------------------------------------------------\n\n 20:41 Exit: Timelimit hit."
+
+	readAll := func(file io.Reader) []dto.LogLine {
+		lineChan := make(chan dto.LogLine)
+		go scripts.NewQuakeLogParser(logger.NewNoop()).ReadLinesFromQuakeLogs(context.Background(), []io.Reader{file}, lineChan)
+
+		lines := []dto.LogLine{}
+		for line := range lineChan {
+			lines = append(lines, line)
+		}
+		return lines
+	}
+
+	// every line is cut by the end of the reads, so the lines are the same for any size of the chunks
+	want := readAll(strings.NewReader(text))
+	if got := readAll(iotest.OneByteReader(strings.NewReader(text))); !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadLinesFromQuakeLogs() = %v, want %v", got, want)
+	}
+	if len(want) != 5 {
+		t.Errorf("ReadLinesFromQuakeLogs() = %v lines, want 5", len(want))
+	}
+}
+
+func TestReadGamesFromQuakeLogs(t *testing.T) {
+	ctx := context.Background()
+	segmentsChan := make(chan dto.GameSegment)
+	files := []io.Reader{
+		strings.NewReader("  0:00 ----\n  0:00 InitGame: \\mapname\\q3dm17\n  1:00 Item: 2 weapon_rocketlauncher\n"),
+		strings.NewReader("  2:00 Exit: Timelimit hit.\n  0:00 InitGame: \\mapname\\q3dm6\n"),
+	}
+
+	go scripts.NewQuakeLogParser(logger.NewNoop()).ReadGamesFromQuakeLogs(ctx, files, segmentsChan)
+
+	got := []dto.GameSegment{}
+	for segment := range segmentsChan {
+		got = append(got, segment)
+	}
+
+	// the game of the first file ends with the file, and the lines of the second file before its first game are outside of any game
+	want := []dto.GameSegment{
+		{Game: 0, Lines: []dto.LogLine{
+			tokenized(dto.LogLine{Text: "  0:00 ----", Number: 1, Offset: 0}),
+		}},
+		{Game: 1, Last: true, Lines: []dto.LogLine{
+			tokenized(dto.LogLine{Text: `  0:00 InitGame: \mapname\q3dm17`, Number: 2, Offset: 12}),
+			tokenized(dto.LogLine{Text: "  1:00 Item: 2 weapon_rocketlauncher", Number: 3, Offset: 45}),
+		}},
+		{Game: 2, Last: true, Lines: []dto.LogLine{
+			tokenized(dto.LogLine{Text: "  2:00 Exit: Timelimit hit.", Number: 1, Offset: 0}),
+			tokenized(dto.LogLine{Text: `  0:00 InitGame: \mapname\q3dm6`, Number: 2, Offset: 28}),
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadGamesFromQuakeLogs() = %+v, want %+v", got, want)
+	}
+}
+
+func TestReadGamesFromQuakeLogs_Empty(t *testing.T) {
+	segmentsChan := make(chan dto.GameSegment)
+
+	go scripts.NewQuakeLogParser(logger.NewNoop()).ReadGamesFromQuakeLogs(context.Background(), []io.Reader{strings.NewReader("")}, segmentsChan)
+
+	got := []dto.GameSegment{}
+	for segment := range segmentsChan {
+		got = append(got, segment)
+	}
+
+	// the segment of game 0 is always sent, so the segments are numbered without gaps
+	want := []dto.GameSegment{{Game: 0, Last: true}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadGamesFromQuakeLogs() = %+v, want %+v", got, want)
+	}
+}
+
+// tokenized returns the line with the token of its text, like the lines sent by the reader.
+func tokenized(line dto.LogLine) dto.LogLine {
+	line.Token, line.Tokenized = entity.Tokenize(line.Text)
+	return line
+}
@@ -0,0 +1,68 @@
+package scripts
+
+import (
+	"github.com/diegoclair/log-parser/domain/entity"
+)
+
+// splitGluedLine splits a line corrupted by a crash of the server, where the start of a record was glued to the next record.
+// It returns the partial record, the record glued after it with its token, and false if the line is not glued.
+// The token of the line and timestamped are the result of entity.Tokenize for the line, so it is not split again.
+//
+// A glued record starts after white spaces with a timestamp, like " 26  0:00 ------".
+// Inside a line that starts with a timestamp only the game boundaries are searched, like "Item: 2 weapon_ro  0:00 InitGame:",
+// so the chat messages with timestamps are not split.
+func splitGluedLine(line string, lineToken entity.Token, timestamped bool) (string, string, entity.Token, bool) {
+	// a line that is itself a game boundary is not glued to it
+	if timestamped && line != "" && isSpace(line[0]) && isGluedRecord(lineToken, true) {
+		return "", "", entity.Token{}, false
+	}
+
+	for i := 1; i < len(line); i++ {
+		if !isSpace(line[i]) || isSpace(line[i-1]) {
+			continue
+		}
+
+		token, ok := entity.Tokenize(line[i:])
+		if !ok || !isGluedRecord(token, timestamped) {
+			continue
+		}
+
+		return line[:i], line[i:], token, true
+	}
+
+	return "", "", entity.Token{}, false
+}
+
+// isGluedRecord reports whether the token found inside a line is a record glued to it.
+func isGluedRecord(token entity.Token, inRecord bool) bool {
+	if inRecord {
+		return token.Kind == entity.KindInitGame || token.Kind == entity.KindShutdownGame || (len(token.Body) >= 10 && isDashes(token.Body))
+	}
+
+	return isDashes(token.Body) || isKindWord(token.Body)
+}
+
+// isDashes reports whether s is a non empty run of dashes, the body of the separator lines.
+func isDashes(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '-' {
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+// isKindWord reports whether s starts with a word followed by a colon, like "Kill:".
+func isKindWord(s string) bool {
+	i := 0
+	for i < len(s) && (s[i] >= 'a' && s[i] <= 'z' || s[i] >= 'A' && s[i] <= 'Z') {
+		i++
+	}
+
+	return i > 0 && i < len(s) && s[i] == ':'
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\f' || c == '\r'
+}